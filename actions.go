@@ -0,0 +1,216 @@
+package poker
+
+import "fmt"
+
+// ActionType of a move a player can make during a betting round
+type ActionType int
+
+// ActionType enums
+const (
+	ActionFold ActionType = iota
+	ActionCheck
+	ActionCall
+	ActionBet
+	ActionRaise
+	ActionAllIn
+)
+
+func (a ActionType) String() string {
+	switch a {
+	case ActionFold:
+		return "Fold"
+	case ActionCheck:
+		return "Check"
+	case ActionCall:
+		return "Call"
+	case ActionBet:
+		return "Bet"
+	case ActionRaise:
+		return "Raise"
+	case ActionAllIn:
+		return "All In"
+	default:
+		panic("invalid action type value")
+	}
+}
+
+// LegalAction is an action a player is allowed to take. Min and Max are the
+// number of chips the player would add to their current bet, the same unit
+// taken by Player.Raise. Both are zero for Fold and Check.
+type LegalAction struct {
+	Type ActionType
+	Min  int
+	Max  int
+}
+
+// Allows reports whether the action permits adding the given amount of chips
+func (la LegalAction) Allows(amount int) bool {
+	return amount >= la.Min && amount <= la.Max
+}
+
+// LegalActions returns the actions the player may take under the game's betting
+// structure, see Game.Betting: a bet must be at least the structure's minimum
+// bet, a raise must be at least the size of the last full raise, and an all-in
+// raise smaller than that does not reopen the betting for players who have
+// already acted. Going All In is only allowed for no more than the structure's
+// maximum raise, or when it does not even cover the call.
+// Players who have folded or are all in have no legal actions.
+// Only the player whose turn it is has legal actions.
+func (g *Game) LegalActions(p *Player) []LegalAction {
+	if g.CurrentPlayer() != p || !p.inHand() || p.PlayerStatus == AllIn || p.money == 0 {
+		return nil
+	}
+
+	actions := []LegalAction{{Type: ActionFold}}
+	toCall := g.highestBet - p.bet
+	if toCall <= 0 {
+		actions = append(actions, LegalAction{Type: ActionCheck})
+	} else {
+		amount := min(toCall, p.money)
+		actions = append(actions, LegalAction{Type: ActionCall, Min: amount, Max: amount})
+	}
+
+	maxRaise, canRaise := g.betting().MaxRaise(g, p)
+	canRaise = canRaise && g.canRaise(p)
+	if canRaise {
+		minRaise := g.highestBet + g.minRaise - p.bet
+		actionType := ActionRaise
+		if g.highestBet == 0 {
+			actionType = ActionBet
+		}
+		if minRaise < p.money && minRaise <= maxRaise {
+			actions = append(actions, LegalAction{Type: actionType, Min: minRaise, Max: min(maxRaise, p.money)})
+		}
+	}
+
+	// Going All In for no more than the call is always allowed, anything more is a raise
+	if (canRaise && p.money <= maxRaise) || p.money <= toCall {
+		actions = append(actions, LegalAction{Type: ActionAllIn, Min: p.money, Max: p.money})
+	}
+	return actions
+}
+
+// legalAction returns the legal action of the given type, if any
+func (g *Game) legalAction(p *Player, t ActionType) (LegalAction, bool) {
+	for _, la := range g.LegalActions(p) {
+		if la.Type == t {
+			return la, true
+		}
+	}
+	return LegalAction{}, false
+}
+
+// canRaise reports whether the betting is open for the player to raise,
+// which is the case until they act and again after every full raise
+func (g *Game) canRaise(p *Player) bool {
+	return !p.hasActed || g.fullBet > p.actedFacing
+}
+
+// recordAction updates the betting round after a player has acted,
+// tracking the size of the last full raise for the min-raise rule,
+// and passes the action on to the next player
+func (g *Game) recordAction(p *Player) error {
+	if p.bet > g.highestBet {
+		if raise := p.bet - g.highestBet; raise >= g.minRaise {
+			g.minRaise = raise
+			g.fullBet = p.bet
+			g.raises++
+		}
+		g.highestBet = p.bet
+	}
+	p.hasActed = true
+	p.actedFacing = g.fullBet
+	g.advanceTurn(g.actionIndex)
+
+	// Betting rounds are only closed automatically once the hand is under way
+	var err error
+	if g.GameStatus >= PreFlop && g.GameStatus < DetermineWinner {
+		err = g.advance()
+	}
+	g.audit(fmt.Sprintf("player %s's action", p.Name))
+	return err
+}
+
+// CurrentPlayer returns the player whose turn it is, or nil when nobody is to act
+func (g *Game) CurrentPlayer() *Player {
+	if g.actionIndex < 0 || g.actionIndex >= len(g.Players) {
+		return nil
+	}
+	return g.Players[g.actionIndex]
+}
+
+// checkTurn returns an ErrNotYourTurn error unless it is the player's turn
+func (g *Game) checkTurn(p *Player) error {
+	current := g.CurrentPlayer()
+	if current == nil {
+		return fmt.Errorf("%w: nobody is to act, player %s cannot act", ErrNotYourTurn, p.Name)
+	}
+	if current != p {
+		return fmt.Errorf("%w: it is player %s's turn, player %s cannot act", ErrNotYourTurn, current.Name, p.Name)
+	}
+	return nil
+}
+
+// advanceTurn passes the action to the next player after index from who still
+// needs to act, skipping folded and All In players. Nobody is to act once the
+// betting round is complete.
+func (g *Game) advanceTurn(from int) {
+	g.actionIndex = -1
+	for offset := 1; offset <= len(g.Players); offset++ {
+		i := (from + offset) % len(g.Players)
+		if g.needsToAct(i) {
+			g.actionIndex = i
+			g.Players[i].StartTurn()
+			return
+		}
+	}
+}
+
+// needsToAct reports whether the player at index i still has to act in the
+// current betting round: they are in the hand and can still bet, someone is left to bet against,
+// and they have either not acted yet or not matched the highest bet
+func (g *Game) needsToAct(i int) bool {
+	p := g.Players[i]
+	if !p.inHand() || p.PlayerStatus == AllIn {
+		return false
+	}
+	inHand, canBet := 0, 0
+	for j := range g.Players {
+		if j == i || !g.Players[j].inHand() {
+			continue
+		}
+		inHand++
+		if g.Players[j].PlayerStatus != AllIn {
+			canBet++
+		}
+	}
+	if inHand == 0 {
+		return false
+	}
+	if p.bet < g.highestBet {
+		return true
+	}
+	return !p.hasActed && canBet > 0
+}
+
+// resetBettingRound prepares the betting state for a new street
+func (g *Game) resetBettingRound() {
+	g.highestBet = 0
+	g.fullBet = 0
+	g.minRaise = g.betting().MinBet(g)
+	g.raises = 0
+	for i := range g.Players {
+		g.Players[i].hasActed = false
+		g.Players[i].actedFacing = 0
+	}
+}
+
+// allowsRaise reports whether adding amount chips is a legal bet or raise
+func (g *Game) allowsRaise(p *Player, amount int) bool {
+	for _, la := range g.LegalActions(p) {
+		if (la.Type == ActionBet || la.Type == ActionRaise) && la.Allows(amount) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,234 @@
+package poker
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// newSeatedGame creates a game with the given players, all ready, without starting it
+func newSeatedGame(startingMoney, bigBlind int, names ...string) *Game {
+	g := NewGame(startingMoney, bigBlind)
+	g.Initialise()
+	for _, name := range names {
+		g.AddPlayer(name)
+	}
+	for i := range g.Players {
+		g.Players[i].IsReady = true
+	}
+	return g
+}
+
+// newStartedGame creates a game with the given players, all ready, and starts it.
+// The first player is the dealer.
+func newStartedGame(startingMoney, bigBlind int, names ...string) *Game {
+	g := newSeatedGame(startingMoney, bigBlind, names...)
+	g.StartGame()
+	g.PreFlop()
+	return g
+}
+
+func TestLegalActionsPreFlop(t *testing.T) {
+	g := newStartedGame(1000, 50, "Alice", "Bob", "Charlie")
+
+	expected := []LegalAction{
+		{Type: ActionFold},
+		{Type: ActionCall, Min: 50, Max: 50},
+		{Type: ActionRaise, Min: 100, Max: 1000},
+		{Type: ActionAllIn, Min: 1000, Max: 1000},
+	}
+	if actions := g.LegalActions(g.getPlayer("Alice", 0)); !reflect.DeepEqual(actions, expected) {
+		t.Errorf("Expected %v, got %v", expected, actions)
+	}
+
+	// Big blind has the option to check
+	g.getPlayer("Alice", 0).Call(g)
+	g.getPlayer("Bob", 1).Call(g)
+	expected = []LegalAction{
+		{Type: ActionFold},
+		{Type: ActionCheck},
+		{Type: ActionRaise, Min: 50, Max: 950},
+		{Type: ActionAllIn, Min: 950, Max: 950},
+	}
+	if actions := g.LegalActions(g.getPlayer("Charlie", 2)); !reflect.DeepEqual(actions, expected) {
+		t.Errorf("Expected %v, got %v", expected, actions)
+	}
+
+	// Players have no legal actions out of turn
+	if actions := g.LegalActions(g.getPlayer("Alice", 0)); actions != nil {
+		t.Errorf("Expected no actions out of turn, got %v", actions)
+	}
+}
+
+func TestLegalActionsMinRaise(t *testing.T) {
+	g := newStartedGame(1000, 50, "Alice", "Bob", "Charlie")
+	alice := g.getPlayer("Alice", 0)
+	bob := g.getPlayer("Bob", 1)
+
+	alice.Raise(150, g) // Raise to $150, a raise of $100
+
+	raise, ok := g.legalAction(bob, ActionRaise)
+	if !ok {
+		t.Fatal("Expected Bob to be able to raise")
+	}
+	// Bob has $25 in and must raise to at least $250
+	if raise.Min != 225 {
+		t.Errorf("Expected minimum raise of 225, got %d", raise.Min)
+	}
+	if err := bob.Raise(224, g); !errors.Is(err, ErrIllegalAction) {
+		t.Errorf("Expected a raise below the last full raise to be rejected, got %v", err)
+	}
+	if err := bob.Raise(225, g); err != nil {
+		t.Errorf("Expected a minimum raise to be accepted, got %v", err)
+	}
+}
+
+func TestLegalActionsIncompleteRaise(t *testing.T) {
+	g := newStartedGame(1000, 50, "Alice", "Bob", "Charlie")
+	alice := g.getPlayer("Alice", 0)
+	bob := g.getPlayer("Bob", 1)
+	charlie := g.getPlayer("Charlie", 2)
+
+	alice.Call(g)
+	bob.Call(g)
+	charlie.Raise(100, g) // Raise to $150
+	alice.money = 150
+	alice.AllIn(g) // All In to $200 is less than a full raise
+
+	// Bob has not acted since the full raise, so he may still raise
+	if _, ok := g.legalAction(bob, ActionRaise); !ok {
+		t.Error("Expected Bob to be able to raise")
+	}
+	bob.Call(g)
+
+	// Charlie has acted on the full raise, the incomplete raise does not reopen the betting
+	expected := []LegalAction{
+		{Type: ActionFold},
+		{Type: ActionCall, Min: 50, Max: 50},
+	}
+	if actions := g.LegalActions(charlie); !reflect.DeepEqual(actions, expected) {
+		t.Errorf("Expected %v, got %v", expected, actions)
+	}
+	if err := charlie.Raise(charlie.money, g); !errors.Is(err, ErrIllegalAction) {
+		t.Errorf("Expected Charlie not to be allowed to go All In, got %v", err)
+	}
+}
+
+func TestLegalActionsPostFlopBet(t *testing.T) {
+	g := newStartedGame(1000, 50, "Alice", "Bob")
+	g.getPlayer("Alice", 0).Call(g)
+	bob := g.getPlayer("Bob", 1)
+	bob.Check(g)
+	g.Flop()
+
+	bet, ok := g.legalAction(bob, ActionBet)
+	if !ok {
+		t.Fatal("Expected Bob to be able to bet")
+	}
+	if bet.Min != 50 || bet.Max != 950 {
+		t.Errorf("Expected bets between 50 and 950, got %d and %d", bet.Min, bet.Max)
+	}
+	if _, ok := g.legalAction(bob, ActionCheck); !ok {
+		t.Error("Expected Bob to be able to check")
+	}
+	if err := bob.Raise(49, g); !errors.Is(err, ErrIllegalAction) {
+		t.Errorf("Expected a bet below the big blind to be rejected, got %v", err)
+	}
+}
+
+func TestLegalActionsShortStack(t *testing.T) {
+	g := newStartedGame(1000, 50, "Alice", "Bob", "Charlie")
+	alice := g.getPlayer("Alice", 0)
+	alice.money = 30
+
+	expected := []LegalAction{
+		{Type: ActionFold},
+		{Type: ActionCall, Min: 30, Max: 30},
+		{Type: ActionAllIn, Min: 30, Max: 30},
+	}
+	if actions := g.LegalActions(alice); !reflect.DeepEqual(actions, expected) {
+		t.Errorf("Expected %v, got %v", expected, actions)
+	}
+
+	alice.Fold(g)
+	if actions := g.LegalActions(alice); actions != nil {
+		t.Errorf("Expected no actions after folding, got %v", actions)
+	}
+}
+
+// thinkingPlayers counts the players whose status is Thinking
+func thinkingPlayers(g *Game) int {
+	count := 0
+	for _, p := range g.Players {
+		if p.PlayerStatus == Thinking {
+			count++
+		}
+	}
+	return count
+}
+
+func TestTurnOrder(t *testing.T) {
+	g := newStartedGame(1000, 50, "Alice", "Bob", "Charlie", "Dave")
+	alice := g.getPlayer("Alice", 0)
+	bob := g.getPlayer("Bob", 1)
+	charlie := g.getPlayer("Charlie", 2)
+	dave := g.getPlayer("Dave", 3)
+
+	// Action starts left of the big blind
+	if g.CurrentPlayer() != dave {
+		t.Fatalf("Expected Dave to act first, got %v", g.CurrentPlayer())
+	}
+	if thinkingPlayers(g) != 1 {
+		t.Errorf("Expected exactly one player to be thinking, got %d", thinkingPlayers(g))
+	}
+
+	// Acting out of turn is rejected
+	if err := bob.Raise(100, g); !errors.Is(err, ErrNotYourTurn) {
+		t.Errorf("Expected Bob's raise out of turn to be rejected, got %v", err)
+	}
+	if bob.bet != 25 {
+		t.Errorf("Expected Bob's bet to be unchanged, got %d", bob.bet)
+	}
+
+	dave.Fold(g)
+	alice.AllIn(g)
+	bob.Call(g)
+	if g.CurrentPlayer() != charlie {
+		t.Fatalf("Expected Charlie to act, got %v", g.CurrentPlayer())
+	}
+	charlie.Call(g)
+	if g.CurrentPlayer() != nil {
+		t.Errorf("Expected the betting round to be complete, got %v", g.CurrentPlayer())
+	}
+	if thinkingPlayers(g) != 0 {
+		t.Errorf("Expected nobody to be thinking, got %d", thinkingPlayers(g))
+	}
+}
+
+func TestTurnOrderPostFlop(t *testing.T) {
+	g := newStartedGame(1000, 50, "Alice", "Bob", "Charlie", "Dave")
+	alice := g.getPlayer("Alice", 0)
+	bob := g.getPlayer("Bob", 1)
+	charlie := g.getPlayer("Charlie", 2)
+	dave := g.getPlayer("Dave", 3)
+
+	dave.Call(g)
+	alice.Call(g)
+	bob.Fold(g)
+	charlie.Check(g)
+	g.Flop()
+
+	// Bob has folded, so the action starts with Charlie after the dealer
+	if g.CurrentPlayer() != charlie {
+		t.Fatalf("Expected Charlie to act first after the flop, got %v", g.CurrentPlayer())
+	}
+	charlie.Check(g)
+	dave.Check(g)
+	if g.CurrentPlayer() != alice {
+		t.Fatalf("Expected the dealer to act last, got %v", g.CurrentPlayer())
+	}
+	alice.Raise(100, g)
+	if g.CurrentPlayer() != charlie {
+		t.Errorf("Expected the action to return to Charlie after the bet, got %v", g.CurrentPlayer())
+	}
+}
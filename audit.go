@@ -0,0 +1,174 @@
+package poker
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Auditor checks the invariants of a game after every action and transition:
+// the chips in play never change, no card is in two places at once, no bet or
+// balance is negative and every pot can be won by a player in the game.
+// Attach it with Game.SetAuditor.
+type Auditor struct {
+	report func(error)
+	total  int // Chips expected in play, raised as players join the game
+}
+
+// NewAuditor creates an auditor that passes every violation it finds to report
+// as an *AuditError, e.g. t.Error in tests or a function that pauses a table
+func NewAuditor(report func(error)) *Auditor {
+	return &Auditor{report: report}
+}
+
+// AuditError describes the invariants a game violated along with the state of
+// the game at that moment. It matches ErrInvariantViolated with errors.Is.
+type AuditError struct {
+	After      string   // The action or transition after which the game was checked
+	Violations []string // Every invariant that does not hold
+	State      string   // Description of the game when the violations were found
+}
+
+func (e *AuditError) Error() string {
+	return fmt.Sprintf("%s after %s: %s", ErrInvariantViolated, e.After, strings.Join(e.Violations, "; "))
+}
+
+func (e *AuditError) Unwrap() error {
+	return ErrInvariantViolated
+}
+
+// HandleEvent keeps track of the chips brought into and taken out of the game
+func (a *Auditor) HandleEvent(e Event) {
+	switch e := e.(type) {
+	case PlayerJoined:
+		a.total += e.Money
+	case ChipsColoredUp:
+		a.total += e.After - e.Before
+	case ChipsAdded:
+		a.total += e.Amount
+	case PlayerLeft:
+		a.total -= e.Money
+	}
+}
+
+// SetAuditor attaches an auditor that checks the game after every action and
+// transition from now on, taking the chips currently in play as the total to
+// conserve. Passing nil detaches it.
+func (g *Game) SetAuditor(a *Auditor) {
+	if g.unsubscribeAuditor != nil {
+		g.unsubscribeAuditor()
+		g.unsubscribeAuditor = nil
+	}
+	g.auditor = a
+	if a != nil {
+		a.total = g.chipsInPlay()
+		g.unsubscribeAuditor = g.Subscribe(a)
+	}
+}
+
+// audit checks the game with its auditor, if one is attached
+func (g *Game) audit(after string) {
+	if g.auditor == nil {
+		return
+	}
+	if err := g.auditor.Check(g, after); err != nil {
+		g.auditor.report(err)
+	}
+}
+
+// Check verifies the invariants of the game, returning an *AuditError listing
+// every violation or nil if they all hold
+func (a *Auditor) Check(g *Game, after string) error {
+	violations := []string{}
+
+	// Chips are only moved between stacks, bets and pots
+	if total := g.chipsInPlay(); total != a.total {
+		violations = append(violations, fmt.Sprintf("chips in play changed from $%d to $%d", a.total, total))
+	}
+
+	// Bets and balances are never negative
+	for _, player := range g.Players {
+		if player.money < 0 {
+			violations = append(violations, fmt.Sprintf("player %s has a negative balance of $%d", player.Name, player.money))
+		}
+		if player.bet < 0 {
+			violations = append(violations, fmt.Sprintf("player %s has a negative bet of $%d", player.Name, player.bet))
+		}
+		if player.contributed < 0 {
+			violations = append(violations, fmt.Sprintf("player %s has contributed a negative $%d", player.Name, player.contributed))
+		}
+	}
+
+	// Every card is in exactly one place
+	seen := map[Card]string{}
+	place := func(where string, cards []Card) {
+		for _, card := range cards {
+			if other, ok := seen[card]; ok {
+				violations = append(violations, fmt.Sprintf("%s is in both %s and %s", card, other, where))
+				continue
+			}
+			seen[card] = where
+		}
+	}
+	if g.Deck != nil {
+		place("the deck", g.Deck.cards)
+	}
+	place("the community cards", g.Community.cards)
+	for _, player := range g.Players {
+		place(fmt.Sprintf("player %s's hand", player.Name), player.cards)
+	}
+
+	// Pots are never negative and, until they are awarded, only eligible to players in the game
+	for j, pot := range g.Pots {
+		if pot.Amount < 0 {
+			violations = append(violations, fmt.Sprintf("pot %d holds a negative $%d", j, pot.Amount))
+		}
+		if pot.Amount > 0 && len(pot.Eligible) == 0 {
+			violations = append(violations, fmt.Sprintf("pot %d holds $%d but nobody is eligible", j, pot.Amount))
+		}
+		ids := map[int]bool{}
+		for _, id := range pot.Eligible {
+			if ids[id] {
+				violations = append(violations, fmt.Sprintf("player %d is eligible for pot %d more than once", id, j))
+			}
+			ids[id] = true
+			if pot.Amount > 0 && g.PlayerByID(id) == nil {
+				violations = append(violations, fmt.Sprintf("player %d is eligible for pot %d but is not in the game", id, j))
+			}
+		}
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+	return &AuditError{After: after, Violations: violations, State: g.describeState()}
+}
+
+// chipsInPlay totals the players' balances and bets and the pots
+func (g *Game) chipsInPlay() int {
+	total := 0
+	for _, player := range g.Players {
+		total += player.money + player.bet
+	}
+	for _, pot := range g.Pots {
+		total += pot.Amount
+	}
+	return total
+}
+
+// describeState writes out the state of the game for an audit report
+func (g *Game) describeState() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "game %s hand %d in %v, highest bet $%d\n", g.ID, g.HandNumber, g.GameStatus, g.highestBet)
+	for _, player := range g.Players {
+		fmt.Fprintf(&b, "player %s: %v, balance $%d, bet $%d, contributed $%d, cards %s\n",
+			player.Name, player.PlayerStatus, player.money, player.bet, player.contributed, cardList(player.cards))
+	}
+	for j, pot := range g.Pots {
+		fmt.Fprintf(&b, "pot %d: $%d for %s\n", j, pot.Amount, strings.Join(playerNames(g.EligiblePlayers(pot)), ", "))
+	}
+	fmt.Fprintf(&b, "community cards %s\n", cardList(g.Community.cards))
+	if g.Deck != nil {
+		fmt.Fprintf(&b, "%d cards in the deck\n", g.Deck.Count())
+	}
+	return b.String()
+}
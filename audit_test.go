@@ -0,0 +1,99 @@
+package poker
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestAuditorSession(t *testing.T) {
+	game := NewGame(500, 50)
+	game.SetAuditor(NewAuditor(func(err error) {
+		t.Errorf("Unexpected violation: %v\n%s", err, err.(*AuditError).State)
+	}))
+	game.Initialise()
+	for _, name := range []string{"Alice", "Bob", "Charlie", "Dave"} {
+		game.AddPlayer(name)
+	}
+	for i := range game.Players {
+		game.Players[i].IsReady = true
+	}
+	if err := game.StartGame(); err != nil {
+		t.Fatalf("Expected the game to start, got %v", err)
+	}
+
+	// Play until one player has won every chip, going All In every third hand
+	for hand := 1; len(game.Players) > 1 && hand <= 100; hand++ {
+		if err := game.PreFlop(); err != nil {
+			t.Fatalf("Expected hand %d to start, got %v", hand, err)
+		}
+		for player := game.CurrentPlayer(); player != nil; player = game.CurrentPlayer() {
+			var err error
+			switch {
+			case hand%3 == 0:
+				err = player.AllIn(game)
+			case player.bet < game.highestBet:
+				err = player.Call(game)
+			default:
+				err = player.Check(game)
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error from %s in hand %d: %v", player.Name, hand, err)
+			}
+		}
+		if len(game.Players) > 1 {
+			if err := game.NextHand(); err != nil {
+				t.Fatalf("Expected hand %d to follow, got %v", hand+1, err)
+			}
+		}
+	}
+}
+
+func TestAuditorViolations(t *testing.T) {
+	game := newStartedGame(1000, 50, "Alice", "Bob")
+	reported := []error{}
+	auditor := NewAuditor(func(err error) {
+		reported = append(reported, err)
+	})
+	game.SetAuditor(auditor)
+
+	if err := auditor.Check(game, "attaching"); err != nil {
+		t.Fatalf("Expected no violations, got %v", err)
+	}
+
+	// Deal one of Alice's cards to the board and lose some of Bob's chips
+	game.Community.Push(game.Players[0].cards[0])
+	game.Players[1].money -= 10
+	game.Players[1].bet = -5
+
+	err := auditor.Check(game, "tampering")
+	if !errors.Is(err, ErrInvariantViolated) {
+		t.Fatalf("Expected %v, got %v", ErrInvariantViolated, err)
+	}
+	var auditErr *AuditError
+	if !errors.As(err, &auditErr) {
+		t.Fatalf("Expected an *AuditError, got %T", err)
+	}
+	if len(auditErr.Violations) != 3 {
+		t.Errorf("Expected 3 violations, got %v", auditErr.Violations)
+	}
+	if !strings.Contains(auditErr.State, "player Bob: Waiting, balance $940, bet $-5") {
+		t.Errorf("Expected the state to describe Bob, got %s", auditErr.State)
+	}
+
+	// Violations are reported after the next action
+	game.getPlayer("Alice", 0).Fold(game)
+	if len(reported) != 1 || !strings.Contains(reported[0].Error(), "after player Alice's action") {
+		t.Errorf("Expected one violation after Alice's action, got %v", reported)
+	}
+
+	// Detached auditors report nothing
+	game.SetAuditor(nil)
+	game.Players[0].money += 10
+	if err := game.NextHand(); err != nil {
+		t.Fatalf("Expected the next hand to start, got %v", err)
+	}
+	if len(reported) != 1 {
+		t.Errorf("Expected no more violations to be reported, got %v", reported)
+	}
+}
@@ -0,0 +1,106 @@
+package poker
+
+// BettingStructure limits the size of bets and raises. The minimum raise is
+// always at least the last full bet or raise of the round, the structure sets
+// the smallest opening bet and the largest bet or raise on top of that.
+// Set Game.Betting to choose one, a game without one plays no-limit.
+type BettingStructure interface {
+	// MinBet returns the smallest opening bet on the current street
+	MinBet(g *Game) int
+	// MaxRaise returns the most chips the player may add to their bet to bet
+	// or raise, before limiting it by their balance. It returns false when
+	// the betting is capped and no more raises are allowed.
+	MaxRaise(g *Game, p *Player) (int, bool)
+}
+
+// NoLimit lets players bet or raise any amount up to their whole balance
+type NoLimit struct{}
+
+// MinBet is the big blind
+func (NoLimit) MinBet(g *Game) int {
+	return g.BigBlind
+}
+
+// MaxRaise is the player's whole balance
+func (NoLimit) MaxRaise(g *Game, p *Player) (int, bool) {
+	return p.money, true
+}
+
+// PotLimit lets players raise up to the size of the pot after calling
+type PotLimit struct{}
+
+// MinBet is the big blind
+func (PotLimit) MinBet(g *Game) int {
+	return g.BigBlind
+}
+
+// MaxRaise is the call plus the size of the pot once the player has called,
+// counting the pots and every bet in front of the players
+func (PotLimit) MaxRaise(g *Game, p *Player) (int, bool) {
+	toCall := g.highestBet - p.bet
+	return toCall + g.potSize() + toCall, true
+}
+
+// FixedLimit only allows bets and raises of a fixed size, the small bet before
+// the flop and on the flop and the big bet on the turn and river. Cap limits
+// the bets and raises in a round, counting the big blind as the first bet
+// before the flop. The cap is lifted once only two players are left in the
+// hand, and a Cap of zero means the betting is never capped.
+type FixedLimit struct {
+	SmallBet int
+	BigBet   int
+	Cap      int
+}
+
+// MinBet is the small bet until the turn and the big bet after it
+func (l FixedLimit) MinBet(g *Game) int {
+	if g.GameStatus >= Turn {
+		return l.BigBet
+	}
+	return l.SmallBet
+}
+
+// MaxRaise is the same as the minimum, a single bet or raise of the street's size
+func (l FixedLimit) MaxRaise(g *Game, p *Player) (int, bool) {
+	if l.Cap > 0 && g.raises >= l.Cap && g.playersInHand() > 2 {
+		return 0, false
+	}
+	return g.highestBet + l.MinBet(g) - p.bet, true
+}
+
+// SpreadLimit lets players bet or raise any amount from Min to Max, with each
+// raise at least as large as the last bet or raise of the round
+type SpreadLimit struct {
+	Min int
+	Max int
+}
+
+// MinBet is the minimum of the spread
+func (l SpreadLimit) MinBet(g *Game) int {
+	return l.Min
+}
+
+// MaxRaise is a raise of the maximum of the spread
+func (l SpreadLimit) MaxRaise(g *Game, p *Player) (int, bool) {
+	return g.highestBet + l.Max - p.bet, true
+}
+
+// betting returns the game's betting structure, which is no-limit by default
+func (g *Game) betting() BettingStructure {
+	if g.Betting == nil {
+		return NoLimit{}
+	}
+	return g.Betting
+}
+
+// potSize totals the pots and the bets in front of the players
+func (g *Game) potSize() int {
+	total := 0
+	for _, pot := range g.Pots {
+		total += pot.Amount
+	}
+	for _, player := range g.Players {
+		total += player.bet
+	}
+	return total
+}
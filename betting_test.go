@@ -0,0 +1,114 @@
+package poker
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestPotLimit(t *testing.T) {
+	g := newSeatedGame(1000, 50, "Alice", "Bob", "Charlie")
+	g.Betting = PotLimit{}
+	g.StartGame()
+	g.PreFlop()
+
+	// Calling 50 makes the pot 125, so Alice can raise to 175
+	expected := []LegalAction{
+		{Type: ActionFold},
+		{Type: ActionCall, Min: 50, Max: 50},
+		{Type: ActionRaise, Min: 100, Max: 175},
+	}
+	if actions := g.LegalActions(g.Players[0]); !reflect.DeepEqual(actions, expected) {
+		t.Errorf("Expected %v, got %v", expected, actions)
+	}
+	if err := g.Players[0].Raise(200, g); !errors.Is(err, ErrIllegalAction) {
+		t.Errorf("Expected raising over the pot to fail with %v, got %v", ErrIllegalAction, err)
+	}
+	if err := g.Players[0].AllIn(g); !errors.Is(err, ErrIllegalAction) {
+		t.Errorf("Expected going All In over the pot to fail with %v, got %v", ErrIllegalAction, err)
+	}
+	g.Players[0].Raise(175, g)
+
+	// Bob calls 150 into a pot of 400, so he can raise another 400 on top
+	expected = []LegalAction{
+		{Type: ActionFold},
+		{Type: ActionCall, Min: 150, Max: 150},
+		{Type: ActionRaise, Min: 275, Max: 550},
+	}
+	if actions := g.LegalActions(g.Players[1]); !reflect.DeepEqual(actions, expected) {
+		t.Errorf("Expected %v, got %v", expected, actions)
+	}
+
+	// Short stacks may still go All In within the limit
+	g.Players[1].money = 500
+	if all, ok := g.legalAction(g.Players[1], ActionAllIn); !ok || all.Min != 500 {
+		t.Errorf("Expected Bob to be able to go All In for 500, got %v", g.LegalActions(g.Players[1]))
+	}
+}
+
+func TestFixedLimit(t *testing.T) {
+	g := newSeatedGame(1000, 50, "Alice", "Bob", "Charlie", "Dave")
+	g.Betting = FixedLimit{SmallBet: 50, BigBet: 100, Cap: 4}
+	g.StartGame()
+	g.PreFlop()
+	alice, bob, charlie, dave := g.Players[0], g.Players[1], g.Players[2], g.Players[3]
+
+	expected := []LegalAction{
+		{Type: ActionFold},
+		{Type: ActionCall, Min: 50, Max: 50},
+		{Type: ActionRaise, Min: 100, Max: 100},
+	}
+	if actions := g.LegalActions(dave); !reflect.DeepEqual(actions, expected) {
+		t.Errorf("Expected %v, got %v", expected, actions)
+	}
+	if err := dave.Raise(150, g); !errors.Is(err, ErrIllegalAction) {
+		t.Errorf("Expected raising twice the limit to fail with %v, got %v", ErrIllegalAction, err)
+	}
+
+	// The big blind and three raises reach the cap
+	dave.Raise(100, g)
+	alice.Raise(150, g)
+	bob.Raise(175, g)
+	expected = []LegalAction{
+		{Type: ActionFold},
+		{Type: ActionCall, Min: 150, Max: 150},
+	}
+	if actions := g.LegalActions(charlie); !reflect.DeepEqual(actions, expected) {
+		t.Errorf("Expected the betting to be capped, got %v", actions)
+	}
+	charlie.Fold(g)
+	dave.Fold(g)
+
+	// The cap is lifted once only two players are left
+	if raise, ok := g.legalAction(alice, ActionRaise); !ok || raise.Min != 100 || raise.Max != 100 {
+		t.Errorf("Expected Alice to be able to raise 100 heads-up, got %v", g.LegalActions(alice))
+	}
+	alice.Call(g)
+
+	// The flop is played with the small bet and the turn with the big bet
+	if bet, _ := g.legalAction(bob, ActionBet); bet.Min != 50 || bet.Max != 50 {
+		t.Errorf("Expected a bet of 50 on the flop, got %v", g.LegalActions(bob))
+	}
+	bob.Check(g)
+	alice.Check(g)
+	if bet, _ := g.legalAction(bob, ActionBet); bet.Min != 100 || bet.Max != 100 {
+		t.Errorf("Expected a bet of 100 on the turn, got %v", g.LegalActions(bob))
+	}
+}
+
+func TestSpreadLimit(t *testing.T) {
+	g := newSeatedGame(1000, 50, "Alice", "Bob", "Charlie")
+	g.Betting = SpreadLimit{Min: 50, Max: 200}
+	g.StartGame()
+	g.PreFlop()
+
+	if raise, _ := g.legalAction(g.Players[0], ActionRaise); raise.Min != 100 || raise.Max != 250 {
+		t.Errorf("Expected raises between 100 and 250, got %v", g.LegalActions(g.Players[0]))
+	}
+	g.Players[0].Raise(200, g) // Raise by 150 to $200
+
+	// Re-raises must be at least as large as the last raise
+	if raise, _ := g.legalAction(g.Players[1], ActionRaise); raise.Min != 325 || raise.Max != 375 {
+		t.Errorf("Expected raises between 325 and 375, got %v", g.LegalActions(g.Players[1]))
+	}
+}
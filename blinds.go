@@ -0,0 +1,156 @@
+package poker
+
+import (
+	"fmt"
+	"log/slog"
+)
+
+// AnteRule decides who posts the ante each hand
+type AnteRule int
+
+// AnteRule enums
+const (
+	AnteEveryPlayer AnteRule = iota // Every player posts the ante
+	AnteBigBlind                    // The big blind posts the ante for the whole table
+	AnteButton                      // The dealer posts the ante for the whole table
+)
+
+func (r AnteRule) String() string {
+	switch r {
+	case AnteEveryPlayer:
+		return "Every Player"
+	case AnteBigBlind:
+		return "Big Blind Ante"
+	case AnteButton:
+		return "Button Ante"
+	default:
+		panic("invalid ante rule value")
+	}
+}
+
+// StraddleRule decides which player may post a live straddle before the cards are acted on
+type StraddleRule int
+
+// StraddleRule enums
+const (
+	NoStraddle          StraddleRule = iota // Straddles are not allowed
+	StraddleUTG                             // The player left of the big blind may straddle
+	StraddleMississippi                     // The dealer may straddle, the action then starts with the small blind
+)
+
+func (r StraddleRule) String() string {
+	switch r {
+	case NoStraddle:
+		return "No Straddle"
+	case StraddleUTG:
+		return "UTG Straddle"
+	case StraddleMississippi:
+		return "Mississippi Straddle"
+	default:
+		panic("invalid straddle rule value")
+	}
+}
+
+// postAntes collects the antes once the blinds have been posted, so a short
+// blind is never left unable to cover it. Antes posted by every player count
+// towards their contribution, while an ante posted for the whole table is dead
+// money in the main pot. Returns the total posted.
+func (g *Game) postAntes(bigBlindIndex int) int {
+	if g.Ante <= 0 {
+		return 0
+	}
+
+	total := 0
+	switch g.AnteRule {
+	case AnteEveryPlayer:
+		for offset := 1; offset <= len(g.Players); offset++ {
+			player := g.Players[(g.DealerIndex+offset)%len(g.Players)]
+			if player.PlayerStatus == SittingOut {
+				continue
+			}
+			posted := player.postAnte(g.Ante)
+			player.contributed += posted
+			total += posted
+			g.emit(BlindPosted{Player: player.Name, Kind: Ante, Amount: posted})
+		}
+	case AnteBigBlind, AnteButton:
+		index := bigBlindIndex
+		if g.AnteRule == AnteButton {
+			// Nobody posts the button ante while the button is dead
+			if !g.Players[g.DealerIndex].IsDealer {
+				return 0
+			}
+			index = g.DealerIndex
+		}
+		player := g.Players[index]
+		total = player.postAnte(g.Ante)
+		g.deadMoney += total
+		g.emit(BlindPosted{Player: player.Name, Kind: Ante, Amount: total})
+	}
+	return total
+}
+
+// straddler returns the index of the player allowed to straddle this hand, or -1 if nobody is
+func (g *Game) straddler() int {
+	if g.headsUp() {
+		return -1
+	}
+	switch g.StraddleRule {
+	case StraddleUTG:
+		bigBlind := g.playerAtSeat(g.bigBlindSeat)
+		for offset := 1; offset < len(g.Players); offset++ {
+			if index := (bigBlind + offset) % len(g.Players); g.Players[index].PlayerStatus != SittingOut {
+				return index
+			}
+		}
+	case StraddleMississippi:
+		if g.Players[g.DealerIndex].IsDealer {
+			return g.DealerIndex
+		}
+	}
+	return -1
+}
+
+// Straddle posts a live straddle of twice the big blind before the preflop
+// betting starts. The straddle acts as a third blind: the action starts with
+// the player to its left, the minimum raise doubles and the straddler is the
+// last to act, with the option to raise. Only the player allowed by the game's
+// StraddleRule may straddle, and not when heads-up.
+func (p *Player) Straddle(g *Game) error {
+	if g.GameStatus != StartGame {
+		return g.wrongState("straddle")
+	}
+	index := g.straddler()
+	if index < 0 || g.Players[index] != p {
+		return fmt.Errorf("%w: player %s may not straddle under the %v rule", ErrIllegalAction, p.Name, g.StraddleRule)
+	}
+	for _, player := range g.Players {
+		if player.hasActed || player.bet > g.BigBlind {
+			return fmt.Errorf("%w: player %s cannot straddle once the betting has started", ErrIllegalAction, p.Name)
+		}
+	}
+	// A missed big blind posted live already puts the player in the pot
+	if p.bet > 0 {
+		return fmt.Errorf("%w: player %s cannot straddle with a blind of $%d posted", ErrIllegalAction, p.Name, p.bet)
+	}
+	amount := 2 * g.BigBlind
+	if amount >= p.money {
+		return fmt.Errorf("%w: player %s needs more than $%d to straddle with a balance of $%d", ErrInsufficientFunds, p.Name, amount, p.money)
+	}
+
+	p.postBlind(amount)
+	g.emit(BlindPosted{Player: p.Name, Kind: Straddle, Amount: amount})
+	g.record.Actions = append(g.record.Actions, Action{Player: p.Name, Straddle: true})
+	g.log().Info("straddle posted", slog.String("player", p.Name), slog.String("rule", g.StraddleRule.String()))
+
+	g.highestBet = amount
+	g.minRaise = amount
+	g.fullBet = amount
+	g.raises++
+	if current := g.CurrentPlayer(); current != nil {
+		current.PlayerStatus = Waiting
+	}
+	g.advanceTurn(index)
+	g.audit(fmt.Sprintf("player %s's straddle", p.Name))
+	return nil
+}
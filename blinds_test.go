@@ -0,0 +1,223 @@
+package poker
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAnteEveryPlayer(t *testing.T) {
+	g := newSeatedGame(1000, 50, "Alice", "Bob", "Charlie")
+	g.Ante = 10
+	g.Players[2].money = 55 // Charlie can post the big blind but only half the ante
+	g.StartGame()
+
+	expected := []int{990, 965, 0}
+	for i, player := range g.Players {
+		if player.money != expected[i] {
+			t.Errorf("Expected %s to have %d, got %d", player.Name, expected[i], player.money)
+		}
+	}
+	if g.Pots[0].Amount != 25 {
+		t.Errorf("Expected the antes to start the main pot with 25, got %d", g.Pots[0].Amount)
+	}
+	if g.Players[2].PlayerStatus != AllIn {
+		t.Errorf("Expected Charlie to be All In, got %v", g.Players[2].PlayerStatus)
+	}
+
+	// Antes do not count towards the bets
+	g.PreFlop()
+	if actions := g.LegalActions(g.Players[0]); actions[1] != (LegalAction{Type: ActionCall, Min: 50, Max: 50}) {
+		t.Errorf("Expected Alice to call 50, got %v", actions)
+	}
+}
+
+func TestAnteBigBlind(t *testing.T) {
+	g := newSeatedGame(1000, 50, "Alice", "Bob", "Charlie")
+	g.Ante = 50
+	g.AnteRule = AnteBigBlind
+	g.Players[2].money = 80 // The big blind comes first, leaving 30 for the ante
+	g.StartGame()
+	g.PreFlop()
+
+	if g.Players[2].money != 0 || g.Players[2].bet != 50 {
+		t.Errorf("Expected Charlie to post the big blind in full and be All In, got bet %d with %d left", g.Players[2].bet, g.Players[2].money)
+	}
+	if g.Pots[0].Amount != 30 {
+		t.Errorf("Expected the main pot to hold the 30 ante, got %d", g.Pots[0].Amount)
+	}
+
+	// Everyone folds to Charlie, who wins the dead ante along with the blinds
+	g.getPlayer("Alice", 0).Fold(g)
+	g.getPlayer("Bob", 1).Fold(g)
+	if money := g.Players[2].money; money != 105 {
+		t.Errorf("Expected Charlie to win 105, got %d", money)
+	}
+}
+
+func TestAnteButton(t *testing.T) {
+	g := newSeatedGame(1000, 50, "Alice", "Bob", "Charlie")
+	g.Ante = 30
+	g.AnteRule = AnteButton
+	g.StartGame()
+
+	expected := []int{970, 975, 950}
+	for i, player := range g.Players {
+		if player.money != expected[i] {
+			t.Errorf("Expected %s to have %d, got %d", player.Name, expected[i], player.money)
+		}
+	}
+}
+
+func TestStraddleUTG(t *testing.T) {
+	g := newSeatedGame(1000, 50, "Alice", "Bob", "Charlie", "Dave")
+	g.StraddleRule = StraddleUTG
+	g.StartGame()
+
+	if err := g.getPlayer("Alice", 0).Straddle(g); !errors.Is(err, ErrIllegalAction) {
+		t.Errorf("Expected the dealer straddling to fail with %v, got %v", ErrIllegalAction, err)
+	}
+	if err := g.getPlayer("Dave", 3).Straddle(g); err != nil {
+		t.Fatalf("Expected Dave to straddle, got %v", err)
+	}
+	if err := g.getPlayer("Dave", 3).Straddle(g); !errors.Is(err, ErrIllegalAction) {
+		t.Errorf("Expected straddling twice to fail with %v, got %v", ErrIllegalAction, err)
+	}
+	g.PreFlop()
+
+	// The action starts left of the straddle and the minimum raise doubles
+	if g.CurrentPlayer() != g.Players[0] {
+		t.Fatalf("Expected Alice to act first, got %v", g.CurrentPlayer())
+	}
+	if thinkingPlayers(g) != 1 {
+		t.Errorf("Expected one player to be thinking, got %v", thinkingPlayers(g))
+	}
+	expected := []LegalAction{
+		{Type: ActionFold},
+		{Type: ActionCall, Min: 100, Max: 100},
+		{Type: ActionRaise, Min: 200, Max: 1000},
+		{Type: ActionAllIn, Min: 1000, Max: 1000},
+	}
+	if actions := g.LegalActions(g.Players[0]); !reflect.DeepEqual(actions, expected) {
+		t.Errorf("Expected %v, got %v", expected, actions)
+	}
+
+	// The straddle is live, Dave has the option once everyone calls
+	g.getPlayer("Alice", 0).Call(g)
+	g.getPlayer("Bob", 1).Call(g)
+	g.getPlayer("Charlie", 2).Call(g)
+	if g.GameStatus != PreFlop || g.CurrentPlayer() != g.Players[3] {
+		t.Fatalf("Expected Dave to have the option, got %v in %v", g.CurrentPlayer(), g.GameStatus)
+	}
+	g.getPlayer("Dave", 3).Check(g)
+	if g.GameStatus != Flop {
+		t.Errorf("Expected game status to be Flop, got %v", g.GameStatus)
+	}
+}
+
+func TestStraddleMississippi(t *testing.T) {
+	g := newSeatedGame(1000, 50, "Alice", "Bob", "Charlie", "Dave")
+	g.StraddleRule = StraddleMississippi
+	g.StartGame()
+
+	if err := g.getPlayer("Dave", 3).Straddle(g); !errors.Is(err, ErrIllegalAction) {
+		t.Errorf("Expected UTG straddling to fail with %v, got %v", ErrIllegalAction, err)
+	}
+	if err := g.getPlayer("Alice", 0).Straddle(g); err != nil {
+		t.Fatalf("Expected the dealer to straddle, got %v", err)
+	}
+	g.PreFlop()
+
+	// The small blind acts first and the dealer last
+	if g.CurrentPlayer() != g.Players[1] {
+		t.Errorf("Expected Bob to act first, got %v", g.CurrentPlayer())
+	}
+	g.getPlayer("Bob", 1).Call(g)
+	g.getPlayer("Charlie", 2).Call(g)
+	g.getPlayer("Dave", 3).Call(g)
+	if g.CurrentPlayer() != g.Players[0] {
+		t.Errorf("Expected Alice to act last, got %v", g.CurrentPlayer())
+	}
+}
+
+func TestStraddleAfterMissedBigBlind(t *testing.T) {
+	g := newSeatedGame(1000, 50, "Alice", "Bob", "Charlie", "Dave")
+	g.StraddleRule = StraddleUTG
+	g.getPlayer("Dave", 3).missedBigBlind = true
+	g.StartGame()
+
+	// Dave is back under the gun with the missed big blind posted live
+	dave := g.getPlayer("Dave", 3)
+	if err := dave.Straddle(g); !errors.Is(err, ErrIllegalAction) {
+		t.Errorf("Expected straddling over a posted big blind to fail with %v, got %v", ErrIllegalAction, err)
+	}
+	if dave.bet != 50 || g.highestBet != 50 {
+		t.Errorf("Expected Dave's bet and the highest bet to stay at $50, got $%d and $%d", dave.bet, g.highestBet)
+	}
+
+	// The hand goes on with Dave first to act on their live blind
+	g.PreFlop()
+	expected := []LegalAction{
+		{Type: ActionFold},
+		{Type: ActionCheck},
+		{Type: ActionRaise, Min: 50, Max: 950},
+		{Type: ActionAllIn, Min: 950, Max: 950},
+	}
+	if g.CurrentPlayer() != dave || !reflect.DeepEqual(g.LegalActions(dave), expected) {
+		t.Fatalf("Expected Dave to act with %v, got %v with %v", expected, g.CurrentPlayer(), g.LegalActions(dave))
+	}
+	if err := dave.Check(g); err != nil {
+		t.Fatalf("Expected Dave to check, got %v", err)
+	}
+	g.getPlayer("Alice", 0).Call(g)
+	g.getPlayer("Bob", 1).Call(g)
+	g.getPlayer("Charlie", 2).Check(g)
+	if g.GameStatus != Flop {
+		t.Errorf("Expected game status to be Flop, got %v", g.GameStatus)
+	}
+}
+
+func TestStraddleAudited(t *testing.T) {
+	g := newSeatedGame(1000, 50, "Alice", "Bob", "Charlie", "Dave")
+	g.StraddleRule = StraddleUTG
+	g.StartGame()
+	reported := []error{}
+	g.SetAuditor(NewAuditor(func(err error) {
+		reported = append(reported, err)
+	}))
+
+	// Chips lost before the straddle are caught right after it
+	g.getPlayer("Alice", 0).money -= 10
+	g.getPlayer("Dave", 3).Straddle(g)
+	if len(reported) != 1 || !strings.Contains(reported[0].Error(), "after player Dave's straddle") {
+		t.Errorf("Expected one violation after Dave's straddle, got %v", reported)
+	}
+}
+
+func TestStraddleErrors(t *testing.T) {
+	g := newSeatedGame(1000, 50, "Alice", "Bob")
+	g.StraddleRule = StraddleMississippi
+	g.StartGame()
+	if err := g.getPlayer("Alice", 0).Straddle(g); !errors.Is(err, ErrIllegalAction) {
+		t.Errorf("Expected straddling heads-up to fail with %v, got %v", ErrIllegalAction, err)
+	}
+
+	g = newSeatedGame(1000, 50, "Alice", "Bob", "Charlie")
+	g.StartGame()
+	if err := g.getPlayer("Alice", 0).Straddle(g); !errors.Is(err, ErrIllegalAction) {
+		t.Errorf("Expected straddling without a straddle rule to fail with %v, got %v", ErrIllegalAction, err)
+	}
+
+	g = newSeatedGame(1000, 50, "Alice", "Bob", "Charlie")
+	g.StraddleRule = StraddleUTG
+	g.Players[0].money = 100
+	g.StartGame()
+	if err := g.getPlayer("Alice", 0).Straddle(g); !errors.Is(err, ErrInsufficientFunds) {
+		t.Errorf("Expected straddling with $100 to fail with %v, got %v", ErrInsufficientFunds, err)
+	}
+	g.PreFlop()
+	if err := g.getPlayer("Alice", 0).Straddle(g); !errors.Is(err, ErrWrongState) {
+		t.Errorf("Expected straddling after the preflop to fail with %v, got %v", ErrWrongState, err)
+	}
+}
@@ -0,0 +1,331 @@
+// Command preflopgen enumerates every preflop all-in matchup between the 169
+// starting hand classes and writes the resulting equity table in the binary
+// format read by package preflop.
+//
+// Every board is evaluated for every pair of hole card combinations. Boards
+// are reduced by suit isomorphism first, which leaves around 134k distinct
+// boards, and 7-card hands are ranked via a lookup of every 5-card hand
+// scored by the poker evaluator.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"runtime"
+	"sort"
+	"sync"
+	"time"
+
+	"poker"
+	"poker/preflop"
+)
+
+const numCards = 52
+
+// binomial[n][k] holds n choose k for the card counts we need
+var binomial [numCards + 1][8]int
+
+func init() {
+	for n := 0; n <= numCards; n++ {
+		binomial[n][0] = 1
+		for k := 1; k < 8 && k <= n; k++ {
+			binomial[n][k] = binomial[n-1][k-1] + binomial[n-1][k]
+		}
+	}
+}
+
+// cardOf converts a card index in 0..51 into a poker.Card
+func cardOf(i int) poker.Card {
+	return poker.Card{Suit: poker.Suit(i / 13), Value: poker.Value(i%13 + 1)}
+}
+
+// comboIndex returns the colex index of a sorted 5-card set
+func comboIndex(c [5]int) int {
+	return binomial[c[0]][1] + binomial[c[1]][2] + binomial[c[2]][3] + binomial[c[3]][4] + binomial[c[4]][5]
+}
+
+// scoreFiveCardHands scores every 5-card hand with the poker evaluator,
+// indexed by comboIndex
+func scoreFiveCardHands() []uint32 {
+	scores := make([]uint32, binomial[numCards][5])
+	hand := make([]poker.Card, 5)
+	forEachSubset(numCards, func(c [5]int) {
+		for i, idx := range c {
+			hand[i] = cardOf(idx)
+		}
+		scores[comboIndex(c)] = poker.BestHand(hand).Score()
+	})
+	return scores
+}
+
+// forEachSubset calls fn with every sorted 5-card subset of 0..n-1
+func forEachSubset(n int, fn func([5]int)) {
+	var c [5]int
+	for c[4] = 4; c[4] < n; c[4]++ {
+		for c[3] = 3; c[3] < c[4]; c[3]++ {
+			for c[2] = 2; c[2] < c[3]; c[2]++ {
+				for c[1] = 1; c[1] < c[2]; c[1]++ {
+					for c[0] = 0; c[0] < c[1]; c[0]++ {
+						fn(c)
+					}
+				}
+			}
+		}
+	}
+}
+
+// board is a canonical board together with the number of boards it represents
+type board struct {
+	cards  [5]int
+	weight uint64
+}
+
+// canonicalBoards groups all boards by suit isomorphism. Equity between hand
+// classes does not change when suits are relabelled, so each group only needs
+// to be evaluated once.
+func canonicalBoards() []board {
+	perms := suitPermutations()
+	weights := map[int]uint64{}
+	forEachSubset(numCards, func(c [5]int) {
+		best := -1
+		for _, perm := range perms {
+			var p [5]int
+			for i, idx := range c {
+				p[i] = perm[idx/13]*13 + idx%13
+			}
+			sort.Ints(p[:])
+			if idx := comboIndex(p); best < 0 || idx < best {
+				best = idx
+			}
+		}
+		weights[best]++
+	})
+
+	boards := make([]board, 0, len(weights))
+	forEachSubset(numCards, func(c [5]int) {
+		if w, ok := weights[comboIndex(c)]; ok {
+			boards = append(boards, board{cards: c, weight: w})
+		}
+	})
+	return boards
+}
+
+// suitPermutations returns all 24 relabellings of the four suits
+func suitPermutations() [][4]int {
+	var perms [][4]int
+	for a := 0; a < 4; a++ {
+		for b := 0; b < 4; b++ {
+			for c := 0; c < 4; c++ {
+				for d := 0; d < 4; d++ {
+					if a != b && a != c && a != d && b != c && b != d && c != d {
+						perms = append(perms, [4]int{a, b, c, d})
+					}
+				}
+			}
+		}
+	}
+	return perms
+}
+
+// holeCombo is one of the 1326 two-card starting hands
+type holeCombo struct {
+	a, b  int
+	class preflop.Class
+}
+
+func holeCombos() []holeCombo {
+	var combos []holeCombo
+	for a := 0; a < numCards; a++ {
+		for b := a + 1; b < numCards; b++ {
+			combos = append(combos, holeCombo{a, b, preflop.ClassOf(cardOf(a), cardOf(b))})
+		}
+	}
+	return combos
+}
+
+// evaluator ranks 7-card hands via the 5-card score lookup
+type evaluator struct {
+	scores []uint32
+}
+
+// score7 returns the score of the best 5-card hand among the board and hole cards
+func (e *evaluator) score7(b [5]int, x, y int) uint32 {
+	cards := [7]int{b[0], b[1], b[2], b[3], b[4], x, y}
+	sort.Ints(cards[:])
+	var best uint32
+	for i := 0; i < 7; i++ {
+		for j := i + 1; j < 7; j++ {
+			var c [5]int
+			n := 0
+			for k := 0; k < 7; k++ {
+				if k != i && k != j {
+					c[n] = cards[k]
+					n++
+				}
+			}
+			if s := e.scores[comboIndex(c)]; s > best {
+				best = s
+			}
+		}
+	}
+	return best
+}
+
+// counts accumulates, for every pair of classes, the number of (hero, villain,
+// board) deals in which hero holds the strictly better hand
+type counts [preflop.NumClasses][preflop.NumClasses]uint64
+
+// classesWithRank lists every class that contains a card of the given value
+var classesWithRank [13][]preflop.Class
+
+func init() {
+	for c := 0; c < preflop.NumClasses; c++ {
+		row, col := c/13, c%13
+		classesWithRank[row] = append(classesWithRank[row], preflop.Class(c))
+		if row != col {
+			classesWithRank[col] = append(classesWithRank[col], preflop.Class(c))
+		}
+	}
+}
+
+// rankOf maps a card index to its row in the class grid
+func rankOf(card int) int {
+	return (13 - card%13) % 13
+}
+
+type scoredCombo struct {
+	score uint32
+	holeCombo
+}
+
+// addBoard counts the wins of every live combo against every other on the board
+func (e *evaluator) addBoard(wins *counts, combos []holeCombo, b board, scored []scoredCombo) {
+	var dead uint64
+	for _, c := range b.cards {
+		dead |= 1 << c
+	}
+	scored = scored[:0]
+	for _, hc := range combos {
+		if dead&(1<<hc.a|1<<hc.b) != 0 {
+			continue
+		}
+		scored = append(scored, scoredCombo{e.score7(b.cards, hc.a, hc.b), hc})
+	}
+	sort.Slice(scored, func(i, j int) bool { return scored[i].score < scored[j].score })
+
+	// Walk the combos from weakest to strongest. below holds how many weaker
+	// combos of each class have been seen, and belowCard the same restricted
+	// to those containing a given card, so combos sharing a card with the one
+	// being counted can be excluded.
+	var below [preflop.NumClasses]uint64
+	var belowCard [numCards][preflop.NumClasses]uint64
+	w := b.weight
+	for start := 0; start < len(scored); {
+		end := start
+		for end < len(scored) && scored[end].score == scored[start].score {
+			end++
+		}
+		for _, sc := range scored[start:end] {
+			row := &wins[sc.class]
+			for v := range below {
+				row[v] += w * below[v]
+			}
+			for _, card := range [2]int{sc.a, sc.b} {
+				for _, v := range classesWithRank[rankOf(card)] {
+					row[v] -= w * belowCard[card][v]
+				}
+			}
+		}
+		for _, sc := range scored[start:end] {
+			below[sc.class]++
+			belowCard[sc.a][sc.class]++
+			belowCard[sc.b][sc.class]++
+		}
+		start = end
+	}
+}
+
+// generate computes the full equity table using the given number of workers
+func generate(workers int) *preflop.Table {
+	start := time.Now()
+	e := &evaluator{scores: scoreFiveCardHands()}
+	log.Printf("scored %d five-card hands in %s", len(e.scores), time.Since(start).Round(time.Millisecond))
+
+	boards := canonicalBoards()
+	log.Printf("reduced to %d canonical boards", len(boards))
+
+	combos := holeCombos()
+	jobs := make(chan board, workers)
+	results := make(chan *counts, workers)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			wins := &counts{}
+			scored := make([]scoredCombo, 0, len(combos))
+			for b := range jobs {
+				e.addBoard(wins, combos, b, scored)
+			}
+			results <- wins
+		}()
+	}
+	go func() {
+		for i, b := range boards {
+			jobs <- b
+			if (i+1)%10000 == 0 {
+				log.Printf("evaluated %d/%d boards", i+1, len(boards))
+			}
+		}
+		close(jobs)
+		wg.Wait()
+		close(results)
+	}()
+
+	var wins counts
+	for partial := range results {
+		for h := range wins {
+			for v := range wins[h] {
+				wins[h][v] += partial[h][v]
+			}
+		}
+	}
+
+	// Every disjoint pair of combos sees C(48, 5) boards, and any deal that
+	// neither hand wins is a tie
+	var deals counts
+	for _, x := range combos {
+		for _, y := range combos {
+			if x.a != y.a && x.a != y.b && x.b != y.a && x.b != y.b {
+				deals[x.class][y.class] += uint64(binomial[numCards-4][5])
+			}
+		}
+	}
+
+	table := &preflop.Table{}
+	for h := range table {
+		for v := range table[h] {
+			ties := deals[h][v] - wins[h][v] - wins[v][h]
+			table[h][v] = (float64(wins[h][v]) + float64(ties)/2) / float64(deals[h][v])
+		}
+	}
+	log.Printf("generated equity table in %s", time.Since(start).Round(time.Second))
+	return table
+}
+
+func main() {
+	out := flag.String("o", "equity.bin", "output file for the binary equity table")
+	workers := flag.Int("workers", runtime.NumCPU(), "number of boards evaluated concurrently")
+	flag.Parse()
+
+	data, err := generate(*workers).MarshalBinary()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*out, data, 0o644); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("wrote %d bytes to %s\n", len(data), *out)
+}
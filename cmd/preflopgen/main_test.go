@@ -0,0 +1,64 @@
+package main
+
+import (
+	"testing"
+
+	"poker"
+)
+
+func TestComboIndex(t *testing.T) {
+	seen := make([]bool, binomial[numCards][5])
+	forEachSubset(numCards, func(c [5]int) {
+		idx := comboIndex(c)
+		if seen[idx] {
+			t.Fatalf("duplicate index %d for %v", idx, c)
+		}
+		seen[idx] = true
+	})
+	for idx, ok := range seen {
+		if !ok {
+			t.Fatalf("index %d is never produced", idx)
+		}
+	}
+}
+
+func TestCanonicalBoards(t *testing.T) {
+	boards := canonicalBoards()
+	if len(boards) != 134459 {
+		t.Errorf("expected 134459 canonical boards, got %d", len(boards))
+	}
+	var total uint64
+	for _, b := range boards {
+		total += b.weight
+	}
+	if total != uint64(binomial[numCards][5]) {
+		t.Errorf("expected weights to sum to %d, got %d", binomial[numCards][5], total)
+	}
+}
+
+func TestHoleCombos(t *testing.T) {
+	combos := holeCombos()
+	if len(combos) != 1326 {
+		t.Fatalf("expected 1326 combos, got %d", len(combos))
+	}
+	for _, hc := range combos {
+		for _, card := range [2]int{hc.a, hc.b} {
+			found := false
+			for _, class := range classesWithRank[rankOf(card)] {
+				found = found || class == hc.class
+			}
+			if !found {
+				t.Errorf("class %s missing from classes with the rank of %s", hc.class, cardOf(card))
+			}
+		}
+	}
+}
+
+func TestCardOf(t *testing.T) {
+	if c := cardOf(0); c != (poker.Card{Suit: poker.Spades, Value: poker.Ace}) {
+		t.Errorf("expected ACE of SPADES, got %s", c)
+	}
+	if c := cardOf(51); c != (poker.Card{Suit: poker.Clubs, Value: poker.King}) {
+		t.Errorf("expected KING of CLUBS, got %s", c)
+	}
+}
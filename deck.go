@@ -3,7 +3,6 @@ package poker
 import (
 	"fmt"
 	"math/rand"
-	"time"
 )
 
 // Suit of a card
@@ -158,12 +157,19 @@ func NewDeck() *Deck {
 }
 
 // Shuffle randomizes the order of cards in the deck
-func (d *Deck) Shuffle() {
+func (d *Deck) Shuffle() error {
+	return d.ShuffleSeed(rand.Int63())
+}
+
+// ShuffleSeed shuffles the deck in the order given by the seed, the same seed
+// always puts a complete deck in the same order
+func (d *Deck) ShuffleSeed(seed int64) error {
 	if len(d.CardStack.cards) != 52 {
-		panic(fmt.Sprintf("cannot shuffle: deck has %d cards, expected 52", len(d.CardStack.cards)))
+		return fmt.Errorf("%w: cannot shuffle a deck of %d cards, expected 52", ErrIncompleteDeck, len(d.CardStack.cards))
 	}
-	rand.Seed(time.Now().UnixNano())
-	rand.Shuffle(len(d.CardStack.cards), func(i, j int) {
+	r := rand.New(rand.NewSource(seed))
+	r.Shuffle(len(d.CardStack.cards), func(i, j int) {
 		d.CardStack.cards[i], d.CardStack.cards[j] = d.CardStack.cards[j], d.CardStack.cards[i]
 	})
+	return nil
 }
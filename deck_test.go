@@ -1,6 +1,8 @@
 package poker
 
 import (
+	"errors"
+	"reflect"
 	"testing"
 )
 
@@ -47,3 +49,31 @@ func TestDefaultDeck(t *testing.T) {
 		t.Error("Expected deck to not be empty")
 	}
 }
+
+func TestShuffleIncompleteDeck(t *testing.T) {
+	deck := NewDeck()
+	if err := deck.Shuffle(); err != nil {
+		t.Fatalf("Expected a full deck to shuffle, got %v", err)
+	}
+	deck.Pop()
+	if err := deck.Shuffle(); !errors.Is(err, ErrIncompleteDeck) {
+		t.Errorf("Expected %v, got %v", ErrIncompleteDeck, err)
+	}
+}
+
+func TestShuffleSeed(t *testing.T) {
+	first, second, other := NewDeck(), NewDeck(), NewDeck()
+	first.ShuffleSeed(42)
+	second.ShuffleSeed(42)
+	other.ShuffleSeed(43)
+
+	if !reflect.DeepEqual(first.cards, second.cards) {
+		t.Errorf("Expected the same seed to give the same order, got %v and %v", first.cards, second.cards)
+	}
+	if reflect.DeepEqual(first.cards, other.cards) {
+		t.Errorf("Expected another seed to give another order, got %v twice", first.cards)
+	}
+	if reflect.DeepEqual(first.cards, NewDeck().cards) {
+		t.Errorf("Expected the deck to be shuffled, got %v", first.cards)
+	}
+}
@@ -0,0 +1,54 @@
+package poker
+
+import "errors"
+
+// Errors returned by Game, Player and Deck methods. They are wrapped with
+// context about the game and player involved, so compare them with errors.Is.
+var (
+	// ErrWrongState is returned when a transition or action is not allowed in the current game state
+	ErrWrongState = errors.New("wrong game state")
+	// ErrNotEnoughPlayers is returned when starting a game with fewer than two players
+	ErrNotEnoughPlayers = errors.New("not enough players")
+	// ErrPlayerNotReady is returned when starting a game before every player is ready
+	ErrPlayerNotReady = errors.New("player not ready")
+	// ErrPlayerNotFound is returned when a player is not seated in the game
+	ErrPlayerNotFound = errors.New("player not found")
+	// ErrNotYourTurn is returned when a player acts while it is not their turn
+	ErrNotYourTurn = errors.New("not your turn")
+	// ErrBettingInProgress is returned when moving to the next street before the betting round is complete
+	ErrBettingInProgress = errors.New("betting round in progress")
+	// ErrIllegalAction is returned when an action or amount is not allowed by the betting rules
+	ErrIllegalAction = errors.New("illegal action")
+	// ErrInsufficientFunds is returned when a player bets more than their balance
+	ErrInsufficientFunds = errors.New("insufficient funds")
+	// ErrDeckEmpty is returned when dealing from an empty deck
+	ErrDeckEmpty = errors.New("deck is empty")
+	// ErrIncompleteDeck is returned when shuffling a deck that is missing cards
+	ErrIncompleteDeck = errors.New("incomplete deck")
+	// ErrHandFull is returned when dealing to a player who already holds two cards
+	ErrHandFull = errors.New("hand is full")
+	// ErrOnBreak is returned when starting a hand during a break in the blind schedule
+	ErrOnBreak = errors.New("on a break")
+	// ErrTooManyPlayers is returned when more players take part than allowed
+	ErrTooManyPlayers = errors.New("too many players")
+	// ErrSeatUnavailable is returned when sitting down in a seat that is taken or does not exist
+	ErrSeatUnavailable = errors.New("seat unavailable")
+	// ErrInvalidBuyIn is returned when a player sits down or rebuys for less than the minimum or more than the maximum buy-in
+	ErrInvalidBuyIn = errors.New("invalid buy-in")
+	// ErrDuplicatePlayer is returned when a player's name is already taken
+	ErrDuplicatePlayer = errors.New("duplicate player")
+	// ErrTableBroken is returned by Tournament.NextHand once a table has been broken up
+	ErrTableBroken = errors.New("table broken")
+	// ErrHandForHand is returned by Tournament.NextHand while a table waits for the others during hand-for-hand play
+	ErrHandForHand = errors.New("playing hand-for-hand")
+	// ErrNothingToUndo is returned by Game.Undo when no action of the current hand is left to take back
+	ErrNothingToUndo = errors.New("nothing to undo")
+	// ErrUndoRefused is returned by Game.Undo when the host does not approve taking the action back
+	ErrUndoRefused = errors.New("undo refused")
+	// ErrInvalidSnapshot is returned when restoring a game from a snapshot that does not describe a valid game
+	ErrInvalidSnapshot = errors.New("invalid snapshot")
+	// ErrInvalidHandHistory is returned when a text hand history cannot be read
+	ErrInvalidHandHistory = errors.New("invalid hand history")
+	// ErrInvariantViolated is reported by an Auditor when the game is in an impossible state
+	ErrInvariantViolated = errors.New("invariant violated")
+)
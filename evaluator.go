@@ -41,7 +41,7 @@ func (h Hand) String() string {
 	return fmt.Sprintf("%s with %v, kickers %v", h.Rank, h.Values, h.Kickers)
 }
 
-// BestHand evaluates the best possible 5-card hand from 7 cards
+// BestHand evaluates the best possible 5-card hand from 5 to 7 cards
 func BestHand(cards []Card) Hand {
 	combinations := generate5CardCombos(cards)
 	bestChan := make(chan Hand, len(combinations)) // Buffered channel to collect results
@@ -58,9 +58,9 @@ func BestHand(cards []Card) Hand {
 
 	// Collect results and determine the best hand
 	var best Hand
-	for range combinations {
+	for i := range combinations {
 		current := <-bestChan
-		if compare := compareRankedHands(current, best); compare > 0 {
+		if compare := compareRankedHands(current, best); i == 0 || compare > 0 {
 			best = current
 		}
 	}
@@ -86,6 +86,9 @@ func evaluateFiveCardHand(cards []Card) Hand {
 	values := make([]int, len(cards))
 	for i, c := range cards {
 		values[i] = int(c.Value)
+		if c.Value == Ace {
+			values[i] = 14 // Aces rank high, the ace-low straight is handled below
+		}
 	}
 	sort.Sort(sort.Reverse(sort.IntSlice(values)))
 
@@ -145,6 +148,22 @@ func evaluateFiveCardHand(cards []Card) Hand {
 	return hand
 }
 
+// Score packs the hand into a single integer that orders the same way as
+// CompareHands, which makes it cheap to sort or compare many ranked hands
+func (h Hand) Score() uint32 {
+	score := uint32(h.Rank)
+	n := 0
+	for _, v := range append(append([]int{}, h.Values...), h.Kickers...) {
+		score = score<<4 | uint32(v)
+		n++
+	}
+	// Pad so that every hand occupies the same number of nibbles
+	for ; n < 5; n++ {
+		score <<= 4
+	}
+	return score
+}
+
 // CompareHands compares two poker hands and returns:
 // 1 if hand1 wins, -1 if hand2 wins, 0 if tie
 func CompareHands(hand1Cards, hand2Cards []Card) int {
@@ -181,11 +200,11 @@ func compareRankedHands(h1, h2 Hand) int {
 }
 
 // EvaluateGame determines the winner(s) among players based on the best hand
-func EvaluateGame(players []Player, community []Card) []Player {
-	var winners []Player
+func EvaluateGame(players []*Player, community []Card) []*Player {
+	var winners []*Player
 
 	for _, player := range players {
-		if player.PlayerStatus == Folded {
+		if !player.inHand() {
 			continue
 		}
 
@@ -195,13 +214,13 @@ func EvaluateGame(players []Player, community []Card) []Player {
 		})
 
 		if len(winners) == 0 {
-			winners = []Player{player}
+			winners = []*Player{player}
 			continue
 		}
 
 		compare := CompareHands(hand, getCombinedHand(winners[0], community))
 		if compare > 0 {
-			winners = []Player{player}
+			winners = []*Player{player}
 		} else if compare == 0 {
 			winners = append(winners, player)
 		}
@@ -211,7 +230,7 @@ func EvaluateGame(players []Player, community []Card) []Player {
 }
 
 // getCombinedHand builds the 7-card hand from player and community
-func getCombinedHand(p Player, community []Card) []Card {
+func getCombinedHand(p *Player, community []Card) []Card {
 	hand := append([]Card{}, community...)
 	p.CardStack.ForEach(func(c Card) {
 		hand = append(hand, c)
@@ -219,20 +238,23 @@ func getCombinedHand(p Player, community []Card) []Card {
 	return hand
 }
 
-// generate5CardCombos generates all 21 possible 5-card hands from 7 cards
+// generate5CardCombos generates all possible 5-card hands from the given cards,
+// e.g. 21 hands from 7 cards or a single hand from 5 cards
 func generate5CardCombos(cards []Card) [][]Card {
 	var combos [][]Card
-	n := len(cards)
-	for i := 0; i < n; i++ {
-		for j := i + 1; j < n; j++ {
-			var combo []Card
-			for k := 0; k < n; k++ {
-				if k != i && k != j {
-					combo = append(combo, cards[k])
-				}
-			}
-			combos = append(combos, combo)
+	var combo []Card
+	var pick func(start int)
+	pick = func(start int) {
+		if len(combo) == 5 {
+			combos = append(combos, append([]Card{}, combo...))
+			return
+		}
+		for k := start; k <= len(cards)-(5-len(combo)); k++ {
+			combo = append(combo, cards[k])
+			pick(k + 1)
+			combo = combo[:len(combo)-1]
 		}
 	}
+	pick(0)
 	return combos
 }
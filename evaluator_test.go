@@ -123,18 +123,71 @@ func TestEvaluateGame(t *testing.T) {
 		card(King, Spades), card(Nine, Spades),
 	}
 
-	p1 := *NewPlayer("Alice", 1000)
+	p1 := NewPlayer("Alice", 1000)
 	p1.CardStack.Push(card(Eight, Spades)) // makes straight flush
 	p1.CardStack.Push(card(Seven, Spades))
 
-	p2 := *NewPlayer("Bob", 1000)
+	p2 := NewPlayer("Bob", 1000)
 	p2.CardStack.Push(card(Ace, Diamonds))
 	p2.CardStack.Push(card(Two, Clubs))
 
-	players := []Player{p1, p2}
+	players := []*Player{p1, p2}
 	winners := EvaluateGame(players, community)
 
 	if len(winners) != 1 || winners[0].Name != "Alice" {
 		t.Errorf("expected Alice to win, got %v", winners)
 	}
 }
+
+func TestAcesRankHigh(t *testing.T) {
+	broadway := []Card{
+		card(Ten, Spades), card(Jack, Hearts), card(Queen, Clubs),
+		card(King, Diamonds), card(Ace, Hearts), card(Two, Spades), card(Four, Clubs),
+	}
+	if hand := BestHand(broadway); hand.Rank != Straight || hand.Values[0] != 14 {
+		t.Errorf("expected an Ace-high straight, got %v", hand)
+	}
+
+	aces := []Card{
+		card(Ace, Spades), card(Ace, Hearts), card(Three, Clubs),
+		card(Five, Diamonds), card(Eight, Hearts), card(Nine, Spades), card(Jack, Clubs),
+	}
+	kings := []Card{
+		card(King, Spades), card(King, Hearts), card(Three, Clubs),
+		card(Five, Diamonds), card(Eight, Hearts), card(Nine, Spades), card(Jack, Clubs),
+	}
+	if result := CompareHands(aces, kings); result != 1 {
+		t.Errorf("expected a pair of Aces to beat a pair of Kings, got result %d", result)
+	}
+}
+
+func TestBestHandFromFiveCards(t *testing.T) {
+	cards := []Card{
+		card(Two, Spades), card(Two, Hearts), card(Nine, Clubs),
+		card(Nine, Diamonds), card(Nine, Hearts),
+	}
+	if hand := BestHand(cards); hand.Rank != FullHouse {
+		t.Errorf("expected %v, got %v", FullHouse, hand.Rank)
+	}
+}
+
+func TestHandScore(t *testing.T) {
+	hands := [][]Card{
+		{card(Two, Clubs), card(Four, Hearts), card(Six, Diamonds), card(Nine, Spades), card(Jack, Clubs)},
+		{card(Two, Clubs), card(Four, Hearts), card(Six, Diamonds), card(Nine, Spades), card(Ace, Clubs)},
+		{card(Two, Clubs), card(Two, Hearts), card(Six, Diamonds), card(Nine, Spades), card(Jack, Clubs)},
+		{card(Two, Clubs), card(Two, Hearts), card(Six, Diamonds), card(Nine, Spades), card(Ace, Clubs)},
+		{card(Ace, Clubs), card(Two, Hearts), card(Three, Diamonds), card(Four, Spades), card(Five, Clubs)},
+		{card(Two, Clubs), card(Three, Hearts), card(Four, Diamonds), card(Five, Spades), card(Six, Clubs)},
+		{card(Two, Clubs), card(Five, Clubs), card(Six, Clubs), card(Nine, Clubs), card(Jack, Clubs)},
+	}
+	for i := 1; i < len(hands); i++ {
+		lower, higher := BestHand(hands[i-1]), BestHand(hands[i])
+		if lower.Score() >= higher.Score() {
+			t.Errorf("expected %v to score below %v", lower, higher)
+		}
+		if compareRankedHands(lower, higher) != -1 {
+			t.Errorf("expected %v to rank below %v", lower, higher)
+		}
+	}
+}
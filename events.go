@@ -0,0 +1,317 @@
+package poker
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Event is implemented by every event a Game emits to its listeners
+type Event interface {
+	event()
+}
+
+// BlindKind tells which blind was posted
+type BlindKind int
+
+// BlindKind enums
+const (
+	SmallBlind BlindKind = iota
+	BigBlind
+	Ante
+	Straddle
+	DeadSmallBlind // A missed small blind posted on return, which does not count towards the bet
+)
+
+func (bk BlindKind) String() string {
+	return [...]string{"small blind", "big blind", "ante", "straddle", "dead small blind"}[bk]
+}
+
+// HandStarted is emitted when a new hand begins. Dealer is empty when the button is dead.
+type HandStarted struct {
+	Hand   int
+	Dealer string
+}
+
+// LevelStarted is emitted when the blind schedule moves to a new level, starting from 1
+type LevelStarted struct {
+	Level    int
+	BigBlind int
+	Ante     int
+}
+
+// ChipsColoredUp is emitted when a player's stack is rounded as the small chips are colored up
+type ChipsColoredUp struct {
+	Player string
+	Before int
+	After  int
+}
+
+// PlayerJoined is emitted when a player is added to the game
+type PlayerJoined struct {
+	Player string
+	Money  int
+}
+
+// BlindPosted is emitted when a player posts a forced bet
+type BlindPosted struct {
+	Player string
+	Kind   BlindKind
+	Amount int
+}
+
+// CardsDealt is emitted when a player is dealt their hole cards
+type CardsDealt struct {
+	Player string
+	Cards  []Card
+}
+
+// ActionTaken is emitted when a player acts. Amount is the number of chips
+// they added and Bet their total bet in the current betting round.
+type ActionTaken struct {
+	Player string
+	Action ActionType
+	Amount int
+	Bet    int
+}
+
+// StreetDealt is emitted when community cards are dealt. Cards holds the
+// newly dealt cards and Board every community card so far.
+type StreetDealt struct {
+	Street GameStatus
+	Cards  []Card
+	Board  []Card
+}
+
+// PotCreated is emitted when the main pot or a side pot is created
+type PotCreated struct {
+	Pot      int // Index in Game.Pots
+	Amount   int
+	Eligible []string
+}
+
+// ShownHand is a player's hand revealed at showdown
+type ShownHand struct {
+	Player string
+	Cards  []Card
+	Hand   Hand
+}
+
+// Showdown is emitted when the remaining players reveal their hands
+type Showdown struct {
+	Hands   []ShownHand
+	Winners []string
+}
+
+// PotAwarded is emitted when a pot is paid out to its winner(s)
+type PotAwarded struct {
+	Pot     int // Index in Game.Pots
+	Amount  int
+	Winners []string
+	Shares  []int // Amount won by each of the winners, who split the pot
+}
+
+// PlayerLeft is emitted when a player leaves the game between hands, taking their money with them
+type PlayerLeft struct {
+	Player string
+	Money  int
+}
+
+// PlayerSatOut is emitted when a player will be dealt out from the next hand on
+type PlayerSatOut struct {
+	Player string
+}
+
+// PlayerSatIn is emitted when a player who has been sitting out will be dealt in again
+type PlayerSatIn struct {
+	Player string
+}
+
+// ChipsAdded is emitted when a player rebuys or tops up their stack, Money is their new balance
+type ChipsAdded struct {
+	Player string
+	Amount int
+	Money  int
+}
+
+// PlayerEliminated is emitted when a player has lost all their money
+type PlayerEliminated struct {
+	Player string
+}
+
+// ActionUndone is emitted when the last action of the hand has been taken back, see Game.Undo
+type ActionUndone struct {
+	Player   string
+	Action   ActionType
+	Amount   int
+	Straddle bool // Whether the straddle was taken back, Action and Amount are unused then
+}
+
+func (HandStarted) event()      {}
+func (LevelStarted) event()     {}
+func (ChipsColoredUp) event()   {}
+func (PlayerJoined) event()     {}
+func (BlindPosted) event()      {}
+func (CardsDealt) event()       {}
+func (ActionTaken) event()      {}
+func (StreetDealt) event()      {}
+func (PotCreated) event()       {}
+func (Showdown) event()         {}
+func (PotAwarded) event()       {}
+func (PlayerLeft) event()       {}
+func (PlayerSatOut) event()     {}
+func (PlayerSatIn) event()      {}
+func (ChipsAdded) event()       {}
+func (PlayerEliminated) event() {}
+func (ActionUndone) event()     {}
+
+// Listener receives the events of a game. Events are delivered synchronously
+// and in order, so a listener that needs to do slow work, or wants them on a
+// channel, should hand them off itself.
+type Listener interface {
+	HandleEvent(e Event)
+}
+
+// ListenerFunc adapts a function to the Listener interface
+type ListenerFunc func(e Event)
+
+// HandleEvent calls f(e)
+func (f ListenerFunc) HandleEvent(e Event) {
+	f(e)
+}
+
+// Subscribe registers a listener for every event emitted by the game from now on.
+// The returned function unsubscribes it.
+func (g *Game) Subscribe(l Listener) func() {
+	g.nextListener++
+	id := g.nextListener
+	g.listeners = append(g.listeners, subscription{id, l})
+	return func() {
+		for i, s := range g.listeners {
+			if s.id == id {
+				g.listeners = append(g.listeners[:i:i], g.listeners[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// subscription is a registered listener
+type subscription struct {
+	id int
+	Listener
+}
+
+// emit logs the event and delivers it to every listener
+func (g *Game) emit(e Event) {
+	g.logEvent(e)
+	for _, s := range g.listeners {
+		s.HandleEvent(e)
+	}
+}
+
+// Narrator is a listener that writes a plain text commentary of the game
+type Narrator struct {
+	w io.Writer
+}
+
+// NewNarrator creates a narrator writing to w, e.g. os.Stdout
+func NewNarrator(w io.Writer) *Narrator {
+	return &Narrator{w}
+}
+
+// HandleEvent writes a line of commentary for the event
+func (n *Narrator) HandleEvent(e Event) {
+	switch e := e.(type) {
+	case HandStarted:
+		if e.Dealer == "" {
+			fmt.Fprintf(n.w, "Hand #%d begins with a dead button.\n", e.Hand)
+		} else {
+			fmt.Fprintf(n.w, "Hand #%d begins, Player %s is the dealer.\n", e.Hand, e.Dealer)
+		}
+	case LevelStarted:
+		fmt.Fprintf(n.w, "Level %d: the big blind is $%d with an ante of $%d.\n", e.Level, e.BigBlind, e.Ante)
+	case ChipsColoredUp:
+		fmt.Fprintf(n.w, "Player %s's stack is colored up from $%d to $%d.\n", e.Player, e.Before, e.After)
+	case PlayerJoined:
+		fmt.Fprintf(n.w, "Player %s joined the game\n", e.Player)
+	case BlindPosted:
+		fmt.Fprintf(n.w, "Player %s posts the %s of $%d.\n", e.Player, e.Kind, e.Amount)
+	case CardsDealt:
+		fmt.Fprintf(n.w, "Player %s is dealt %d cards.\n", e.Player, len(e.Cards))
+	case ActionTaken:
+		switch e.Action {
+		case ActionFold:
+			fmt.Fprintf(n.w, "Player %s folds.\n", e.Player)
+		case ActionCheck:
+			fmt.Fprintf(n.w, "Player %s checks.\n", e.Player)
+		case ActionCall:
+			fmt.Fprintf(n.w, "Player %s calls $%d\n", e.Player, e.Bet)
+		case ActionBet, ActionRaise:
+			fmt.Fprintf(n.w, "Player %s raised by $%d\n", e.Player, e.Amount)
+		case ActionAllIn:
+			fmt.Fprintf(n.w, "Player %s goes All In for $%d!\n", e.Player, e.Bet)
+		}
+	case StreetDealt:
+		fmt.Fprintln(n.w, "Community cards dealt:")
+		for _, c := range e.Board {
+			fmt.Fprintln(n.w, c)
+		}
+	case PotCreated:
+		fmt.Fprintf(n.w, "Pot %d created with $%d for %s.\n", e.Pot, e.Amount, strings.Join(e.Eligible, ", "))
+	case Showdown:
+		for _, h := range e.Hands {
+			fmt.Fprintf(n.w, "Player %s shows %s: %s\n", h.Player, cardList(h.Cards), h.Hand.Rank)
+		}
+		if len(e.Winners) == 1 {
+			fmt.Fprintf(n.w, "The winner is %s!\n", e.Winners[0])
+		} else {
+			fmt.Fprintf(n.w, "The winners are: %s!\n", strings.Join(e.Winners, ", "))
+		}
+	case PotAwarded:
+		fmt.Fprintf(n.w, "%s won $%d from pot %d.\n", strings.Join(e.Winners, ", "), e.Amount, e.Pot)
+	case PlayerLeft:
+		fmt.Fprintf(n.w, "Player %s left the game with $%d.\n", e.Player, e.Money)
+	case PlayerSatOut:
+		fmt.Fprintf(n.w, "Player %s sits out.\n", e.Player)
+	case PlayerSatIn:
+		fmt.Fprintf(n.w, "Player %s is back in the game.\n", e.Player)
+	case ChipsAdded:
+		fmt.Fprintf(n.w, "Player %s adds $%d and now has $%d.\n", e.Player, e.Amount, e.Money)
+	case PlayerEliminated:
+		fmt.Fprintf(n.w, "Player %s has been eliminated.\n", e.Player)
+	case ActionUndone:
+		if e.Straddle {
+			fmt.Fprintf(n.w, "Player %s's straddle is taken back.\n", e.Player)
+		} else {
+			fmt.Fprintf(n.w, "Player %s's %s is taken back.\n", e.Player, strings.ToLower(e.Action.String()))
+		}
+	}
+}
+
+// cardList joins the cards into a single comma separated string
+func cardList(cards []Card) string {
+	names := make([]string, len(cards))
+	for i, c := range cards {
+		names[i] = c.String()
+	}
+	return strings.Join(names, ", ")
+}
+
+// playerNames returns the names of the players
+func playerNames(players []*Player) []string {
+	names := make([]string, len(players))
+	for i, p := range players {
+		names[i] = p.Name
+	}
+	return names
+}
+
+// playerIDs returns the IDs of the players
+func playerIDs(players []*Player) []int {
+	ids := make([]int, len(players))
+	for i, p := range players {
+		ids[i] = p.ID
+	}
+	return ids
+}
@@ -0,0 +1,140 @@
+package poker
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// eventRecorder collects every event it receives
+type eventRecorder struct {
+	events []Event
+}
+
+func (r *eventRecorder) HandleEvent(e Event) {
+	r.events = append(r.events, e)
+}
+
+// types returns the type names of the recorded events
+func (r *eventRecorder) types() []string {
+	names := make([]string, len(r.events))
+	for i, e := range r.events {
+		names[i] = strings.TrimPrefix(fmt.Sprintf("%T", e), "poker.")
+	}
+	return names
+}
+
+func TestEvents(t *testing.T) {
+	game := NewGame(1000, 50)
+	recorder := &eventRecorder{}
+	game.Subscribe(recorder)
+	game.Initialise()
+	game.AddPlayer("Alice")
+	game.AddPlayer("Bob")
+	for i := range game.Players {
+		game.Players[i].IsReady = true
+	}
+	game.StartGame()
+	game.PreFlop()
+	p1 := game.getPlayer("Alice", 0)
+	p2 := game.getPlayer("Bob", 1)
+	p1.Call(game)
+	p2.Raise(50, game)
+	p1.Call(game)
+	for range 3 {
+		p2.Check(game)
+		p1.Check(game)
+	}
+
+	expected := []string{
+		"PlayerJoined", "PlayerJoined",
+		"HandStarted",
+		"CardsDealt", "CardsDealt",
+		"BlindPosted", "BlindPosted",
+		"PotCreated",
+		"ActionTaken", "ActionTaken", "ActionTaken",
+		"StreetDealt", "ActionTaken", "ActionTaken",
+		"StreetDealt", "ActionTaken", "ActionTaken",
+		"StreetDealt", "ActionTaken", "ActionTaken",
+		"Showdown", "PotAwarded",
+	}
+	if types := recorder.types(); !reflect.DeepEqual(types, expected) {
+		t.Fatalf("Expected events %v, got %v", expected, types)
+	}
+
+	if e := recorder.events[9].(ActionTaken); e != (ActionTaken{Player: "Bob", Action: ActionRaise, Amount: 50, Bet: 100}) {
+		t.Errorf("Unexpected raise event %+v", e)
+	}
+	if e := recorder.events[11].(StreetDealt); e.Street != Flop || len(e.Cards) != 3 || len(e.Board) != 3 {
+		t.Errorf("Unexpected flop event %+v", e)
+	}
+	if e := recorder.events[17].(StreetDealt); e.Street != River || len(e.Cards) != 1 || len(e.Board) != 5 {
+		t.Errorf("Unexpected river event %+v", e)
+	}
+	if e := recorder.events[21].(PotAwarded); e.Amount != 200 || len(e.Winners) == 0 {
+		t.Errorf("Unexpected pot awarded event %+v", e)
+	}
+}
+
+func TestEventsUncontested(t *testing.T) {
+	game := newStartedGame(1000, 50, "Alice", "Bob", "Charlie")
+	recorder := &eventRecorder{}
+	game.Subscribe(recorder)
+	game.getPlayer("Alice", 0).Fold(game)
+	game.getPlayer("Bob", 1).Fold(game)
+
+	expected := []Event{
+		ActionTaken{Player: "Alice", Action: ActionFold},
+		ActionTaken{Player: "Bob", Action: ActionFold, Bet: 25},
+		PotAwarded{Pot: 0, Amount: 75, Winners: []string{"Charlie"}, Shares: []int{75}},
+	}
+	if !reflect.DeepEqual(recorder.events, expected) {
+		t.Errorf("Expected events %v, got %v", expected, recorder.events)
+	}
+}
+
+func TestUnsubscribe(t *testing.T) {
+	game := NewGame(1000, 50)
+	first, second := &eventRecorder{}, &eventRecorder{}
+	unsubscribe := game.Subscribe(first)
+	game.Subscribe(second)
+	game.AddPlayer("Alice")
+	unsubscribe()
+	game.AddPlayer("Bob")
+
+	if len(first.events) != 1 {
+		t.Errorf("Expected 1 event before unsubscribing, got %d", len(first.events))
+	}
+	if len(second.events) != 2 {
+		t.Errorf("Expected 2 events, got %d", len(second.events))
+	}
+}
+
+func TestNarrator(t *testing.T) {
+	var out bytes.Buffer
+	game := NewGame(1000, 50)
+	game.Subscribe(NewNarrator(&out))
+	game.Initialise()
+	game.AddPlayer("Alice")
+	game.AddPlayer("Bob")
+	for i := range game.Players {
+		game.Players[i].IsReady = true
+	}
+	game.StartGame()
+	game.PreFlop()
+	game.getPlayer("Alice", 0).Fold(game)
+
+	for _, line := range []string{
+		"Player Alice joined the game",
+		"Player Alice posts the small blind of $25.",
+		"Player Bob posts the big blind of $50.",
+		"Player Alice folds.",
+		"Bob won $75 from pot 0.",
+	} {
+		if !strings.Contains(out.String(), line) {
+			t.Errorf("Expected narration to contain %q, got:\n%s", line, out.String())
+		}
+	}
+}
@@ -1,6 +1,10 @@
 package poker
 
-import "fmt"
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+)
 
 // Status of a game
 type GameStatus int
@@ -17,454 +21,822 @@ const (
 	DetermineWinner                     // Determine who the winner(s) are
 )
 
-// Pot represents the accumulated money from a round of betting and the eligible players
+// String representation of a game status
+func (gs GameStatus) String() string {
+	switch gs {
+	case Init:
+		return "Init"
+	case WaitingForPlayers:
+		return "Waiting For Players"
+	case StartGame:
+		return "Start Game"
+	case PreFlop:
+		return "PreFlop"
+	case Flop:
+		return "Flop"
+	case Turn:
+		return "Turn"
+	case River:
+		return "River"
+	case DetermineWinner:
+		return "Determine Winner"
+	default:
+		panic("invalid game status value")
+	}
+}
+
+// Pot represents the accumulated money from a round of betting and the IDs of
+// the players eligible to win it, see Game.EligiblePlayers
 type Pot struct {
 	Amount   int
-	Eligible []Player
+	Eligible []int
 }
 
 // Game structure
 type Game struct {
-	Players []Player
+	ID         string    // Identifies the game in log messages
+	HandNumber int       // Counts the hands played, starting from 1
+	Players    []*Player // In seat order, by pointer so players stay valid as others come and go
 	GameStatus
 	StartingMoney int
 	BigBlind      int
+	SmallBlind    int              // Posted by the small blind, half the big blind when 0
+	MaxSeats      int              // Seats at the table, numbered from 0, see SitDown
+	MinBuyIn      int              // Least money a player may sit down with, no minimum when 0
+	MaxBuyIn      int              // Most money a player may sit down or rebuy to, no maximum when 0
+	Ante          int              // Posted every hand by every player, or once for the table, see AnteRule
+	AnteRule      AnteRule         // Who posts the ante
+	StraddleRule  StraddleRule     // Who may post a live straddle, see Player.Straddle
+	Betting       BettingStructure // Limits the bets and raises, no-limit when nil
+	Schedule      *BlindSchedule   // Raises BigBlind and Ante between hands, if set
 	DealerIndex   int
+	OddChipRule   OddChipRule       // Who receives the remainder of a split pot
+	SeedSource    func() int64      // Picks the seed each hand's deck is shuffled with, at random when nil
+	ApproveUndo   func(Action) bool // Asked by Undo before an action is taken back, every undo is allowed when nil
 	Deck          *Deck
 	Community     CardStack
 	Pots          []Pot // Main pot and optional side pots
+	deadMoney     int   // Antes posted for the whole table, which belong to the main pot
 	highestBet    int   // Tracks the current highest bet during the game
+	minRaise      int   // Size of the last full bet or raise, the minimum for the next raise
+	raises        int   // Full bets and raises in the current betting round, for the cap in fixed-limit
+	fullBet       int   // Bet level of the last full bet or raise, which reopens the betting
+	actionIndex   int   // Index of the player whose turn it is, -1 when nobody is to act
+	listeners     []subscription
+	nextListener  int
+	nextPlayerID  int
+	logger        *slog.Logger
+	record        HandRecord // The hand being played, see Game.HandRecord
+	stackedDeck   []Card     // Deals the next hand in this order instead of shuffling, for replays
+
+	auditor            *Auditor
+	unsubscribeAuditor func()
+
+	// Seats of the button and blinds, which stay put when their players leave
+	// so the button and blinds can move past the empty seats
+	buttonSeat     int
+	smallBlindSeat int
+	bigBlindSeat   int
 }
 
+// DefaultMaxSeats is the number of seats at the table of a new game
+const DefaultMaxSeats = 10
+
 // NewGame creates a new game instance with initial values
 func NewGame(startingMoney, bigBlind int) *Game {
-	fmt.Println("Starting a new game!")
 	deck := NewDeck()
-	deck.Shuffle()
-	return &Game{
-		Players:       []Player{},
+	deck.Shuffle() // A new deck is always complete
+	g := &Game{
+		ID:            newGameID(),
+		Players:       []*Player{},
 		GameStatus:    Init,
 		StartingMoney: startingMoney,
 		BigBlind:      bigBlind,
+		MaxSeats:      DefaultMaxSeats,
 		DealerIndex:   -1,
 		Deck:          deck,
+		actionIndex:   -1,
 	}
+	g.log().Info("starting a new game", slog.Int("starting_money", startingMoney), slog.Int("big_blind", bigBlind))
+	return g
+}
+
+// AddPlayer seats a player with the starting money in the first empty seat,
+// see SitDown. Returns ErrTooManyPlayers when every seat is taken, or
+// ErrDuplicatePlayer when the name is taken by another player.
+func (g *Game) AddPlayer(name string) error {
+	return g.addPlayer(name, g.emptySeat(), g.StartingMoney, g.HandNumber > 0)
 }
 
-// AddPlayer to the game instance
-func (g *Game) AddPlayer(name string) {
-	p := NewPlayer(name, g.StartingMoney)
-	g.Players = append(g.Players, *p)
-	fmt.Printf("Player %s joined the game\n", p.Name)
+// addPlayer seats a player bringing the given money to the game, in the empty
+// seat returned by emptySeat, and gives them a new ID. Names must be unique
+// among the seated players. Players who join during a hand are dealt in from
+// the next hand, owing the big blind if owesBigBlind is set.
+func (g *Game) addPlayer(name string, seat, money int, owesBigBlind bool) error {
+	if g.getPlayer(name, -1) != nil {
+		return fmt.Errorf("%w: a player called %s is already seated", ErrDuplicatePlayer, name)
+	}
+	if seat < 0 {
+		return fmt.Errorf("%w: all %d seats are taken, player %s cannot join", ErrTooManyPlayers, g.MaxSeats, name)
+	}
+	g.nextPlayerID++
+	p := NewPlayer(name, money)
+	p.ID = g.nextPlayerID
+	p.seat = seat
+	p.missedBigBlind = owesBigBlind
+	if g.handInProgress() {
+		p.PlayerStatus = SittingOut
+	}
+	g.Players = append(g.Players, p)
+	g.emit(PlayerJoined{Player: p.Name, Money: p.money})
+	return nil
+}
+
+// handInProgress reports whether the cards have been dealt and the hand is not over yet
+func (g *Game) handInProgress() bool {
+	return g.GameStatus >= StartGame && g.GameStatus < DetermineWinner
 }
 
 // Initialise transitions the game from Init to WaitingForPlayers state
-func (g *Game) Initialise() {
+func (g *Game) Initialise() error {
 	if g.GameStatus != Init {
-		fmt.Println("Game cannot be initialised. Current state:", g.GameStatus)
-		return
+		return g.wrongState("be initialised")
 	}
 	g.GameStatus = WaitingForPlayers
-	fmt.Println("Game has been initialised. Waiting for Players to join.")
+	g.log().Info("game has been initialised, waiting for players to join")
+	return nil
+}
+
+// wrongState returns an ErrWrongState error describing the attempted action
+func (g *Game) wrongState(action string) error {
+	return fmt.Errorf("%w: game cannot %s, current state: %s", ErrWrongState, action, g.GameStatus)
 }
 
 // StartGame transitions the game from WaitingForPlayers to StartGame
-func (g *Game) StartGame() {
+func (g *Game) StartGame() error {
 	if g.GameStatus != WaitingForPlayers {
-		fmt.Println("Game cannot Start. Current state:", g.GameStatus)
-		return
+		return g.wrongState("start")
+	}
+
+	if g.playersInGame() < 2 {
+		return fmt.Errorf("%w: game cannot start with %d players, at least 2 are required", ErrNotEnoughPlayers, g.playersInGame())
 	}
 
 	// Ensure all Players are ready
 	for _, player := range g.Players {
 		if !player.IsReady {
-			fmt.Printf("Player %s is not ready. Cannot Start the game.\n", player.Name)
-			return
+			return fmt.Errorf("%w: player %s is not ready, cannot start the game", ErrPlayerNotReady, player.Name)
 		}
 	}
 
+	if err := g.applySchedule(true); err != nil {
+		return err
+	}
+
 	// Transition to StartGame state
 	g.GameStatus = StartGame
-	fmt.Println("Game has Started. Setting up the game...")
+	g.log().Info("game has started, setting up the game", slog.Int("players", len(g.Players)))
 
-	// Set dealer position
-	g.DealerIndex = 0
-	fmt.Printf("Player %s is the dealer.\n", g.Players[g.DealerIndex].Name)
+	// The player in the lowest seat is the dealer
+	g.buttonSeat = g.nextSeatInGame(g.seatCount() - 1)
 
-	// Deal two cards to each player
-	deck := NewDeck()
-	deck.Shuffle()
-	for i := range g.Players {
-		g.Players[i].Deal(deck)
-		g.Players[i].Deal(deck)
+	// Handle blinds
+	// Special case for two Players: the dealer posts the small blind
+	if g.headsUp() {
+		g.smallBlindSeat = g.buttonSeat
+	} else {
+		g.smallBlindSeat = g.nextSeatInGame(g.buttonSeat)
 	}
+	g.bigBlindSeat = g.nextSeatInGame(g.smallBlindSeat)
 
-	// Handle blinds
-	smallBlindIndex := -1
-	bigBlindIndex := -1
+	return g.startHand()
+}
+
+// NextHand starts the next hand of the session once the winners have been determined.
+// Hands, bets and the board are cleared, a fresh deck is shuffled and the
+// button moves on before the blinds are posted again.
+//
+// The button follows the dead button rule: the big blind always moves to the
+// next player, last hand's big blind posts the small blind and last hand's
+// small blind gets the button. When those players have been eliminated the
+// small blind is not posted, or the button stays on the empty seat. Players
+// sitting out are skipped and miss the blinds passing their seat, see SitOut.
+//
+// Heads-up the dealer posts the small blind, acting first before the flop and
+// last after it. When the game goes heads-up the big blind still moves on, and
+// the other player takes the button, so nobody posts the big blind twice in a row.
+func (g *Game) NextHand() error {
+	if g.GameStatus != DetermineWinner {
+		return g.wrongState("start the next hand")
+	}
+
+	if g.playersInGame() < 2 {
+		return fmt.Errorf("%w: next hand cannot start with %d players, at least 2 are required", ErrNotEnoughPlayers, g.playersInGame())
+	}
+
+	if err := g.applySchedule(false); err != nil {
+		return err
+	}
+
+	g.GameStatus = StartGame
+	g.log().Info("starting the next hand", slog.Int("players", len(g.Players)))
 
-	// Special case for two Players: small blind and big blind are the same player
-	if len(g.Players) == 2 {
-		smallBlindIndex = g.DealerIndex
-		bigBlindIndex = (g.DealerIndex + 1) % len(g.Players)
+	// Players sitting out between the big blinds miss it
+	nextBigBlind := g.nextSeatInGame(g.bigBlindSeat)
+	for seat := (g.bigBlindSeat + 1) % g.seatCount(); seat != nextBigBlind; seat = (seat + 1) % g.seatCount() {
+		if index := g.playerAtSeat(seat); index >= 0 {
+			g.Players[index].missedBigBlind = true
+		}
+	}
+
+	// Heads-up the dealer posts the small blind and the other player the big blind
+	if g.headsUp() {
+		g.buttonSeat = g.nextSeatInGame(nextBigBlind)
+		g.smallBlindSeat = g.buttonSeat
 	} else {
-		smallBlindIndex = (g.DealerIndex + 1) % len(g.Players)
-		bigBlindIndex = (g.DealerIndex + 2) % len(g.Players)
+		g.buttonSeat = g.smallBlindSeat
+		g.smallBlindSeat = g.bigBlindSeat
+		if index := g.playerAtSeat(g.smallBlindSeat); index >= 0 && g.Players[index].sittingOut {
+			g.Players[index].missedSmallBlind = true
+		}
 	}
+	g.bigBlindSeat = nextBigBlind
 
-	g.Players[smallBlindIndex].Raise(g.BigBlind/2, g)
-	fmt.Printf("Player %s posts the small blind of $%d.\n", g.Players[smallBlindIndex].Name, g.BigBlind/2)
+	return g.startHand()
+}
 
-	g.Players[bigBlindIndex].Raise(g.BigBlind, g)
-	fmt.Printf("Player %s posts the big blind of $%d.\n", g.Players[bigBlindIndex].Name, g.BigBlind)
+// smallBlind returns the amount of the small blind
+func (g *Game) smallBlind() int {
+	if g.SmallBlind > 0 {
+		return g.SmallBlind
+	}
+	return g.BigBlind / 2
+}
 
-	// Initialize the Community CardStack
-	g.Community = CardStack{}
+// headsUp reports whether only two players are left in the game
+func (g *Game) headsUp() bool {
+	return g.playersInGame() == 2
+}
 
-	// Initialize the main pot
-	g.Pots = []Pot{{Amount: 0, Eligible: g.Players}}
+// playersInGame counts the seated players who are not sitting out
+func (g *Game) playersInGame() int {
+	count := 0
+	for _, player := range g.Players {
+		if !player.sittingOut {
+			count++
+		}
+	}
+	return count
 }
 
-// AddBetsToPots adds the current bets of players to the pots
-func (g *Game) AddBetsToPots() {
+// seatCount returns the number of seats at the table, which is never less
+// than needed for the players seated
+func (g *Game) seatCount() int {
+	count := max(g.MaxSeats, 1)
+	for _, player := range g.Players {
+		count = max(count, player.seat+1)
+	}
+	return count
+}
+
+// playerAtSeat returns the index of the player at the seat, or -1 if the seat is empty
+func (g *Game) playerAtSeat(seat int) int {
 	for i := range g.Players {
-		player := &g.Players[i]
+		if g.Players[i].seat == seat {
+			return i
+		}
+	}
+	return -1
+}
 
-		// Skip players with no bets
-		if player.bet == 0 {
-			continue
+// nextSeatInGame returns the first seat after the given one whose player is
+// still in the game and not sitting out
+func (g *Game) nextSeatInGame(seat int) int {
+	for offset := 1; offset <= g.seatCount(); offset++ {
+		next := (seat + offset) % g.seatCount()
+		if index := g.playerAtSeat(next); index >= 0 && !g.Players[index].sittingOut {
+			return next
 		}
+	}
+	return seat
+}
 
-		switch player.PlayerStatus {
-		case Called, Checked: // Handle both Called and Checked statuses
-			// Add their bet to the latest side pot they are eligible for, if side pots exist
-			addedToSidePot := false
-			for j := len(g.Pots) - 1; j > 0; j-- { // Skip the main pot (index 0)
-				if containsPlayer(g.Pots[j].Eligible, *player) {
-					g.Pots[j].Amount += player.bet
-					player.bet = 0
-					addedToSidePot = true
-					break
-				}
-			}
-			// If no side pot exists or they are not eligible for any side pot, add to the main pot
-			if !addedToSidePot {
-				g.Pots[0].Amount += player.bet
-				player.bet = 0
-			}
+// startHand resets the players and the board, deals a fresh deck and posts
+// the blinds for the seats chosen by StartGame or NextHand. Players sitting out,
+// or waiting for the big blind, are not dealt in.
+func (g *Game) startHand() error {
+	// Record the table as it is before the hand, for the hand to be replayed
+	seed := g.newSeed()
+	table := g.Snapshot()
+	table.Hand = nil
+	g.record = HandRecord{Seed: seed, Table: table, Actions: []Action{}}
+	g.HandNumber++
+
+	// Shuffle a fresh deck for the hand, unless a replay has stacked it
+	g.Deck = NewDeck()
+	if g.stackedDeck != nil {
+		g.Deck = &Deck{CardStack{slices.Clone(g.stackedDeck)}}
+		g.record.Deck, g.stackedDeck = g.stackedDeck, nil
+	} else if err := g.Deck.ShuffleSeed(seed); err != nil {
+		return err
+	}
 
-		case Folded:
-			// Add their bet to the latest side pot they were eligible for
-			addedToSidePot := false
-			for j := len(g.Pots) - 1; j >= 0; j-- {
-				if containsPlayer(g.Pots[j].Eligible, *player) {
-					g.Pots[j].Amount += player.bet
-					player.bet = 0
-					addedToSidePot = true
-					break
-				}
-			}
-			// If not eligible for any side pot, add to the main pot
-			if !addedToSidePot {
-				g.Pots[0].Amount += player.bet
-				player.bet = 0
-			}
+	// Clear everything left over from the previous hand, keeping the players in seat order
+	slices.SortStableFunc(g.Players, func(a, b *Player) int {
+		return a.seat - b.seat
+	})
+	g.Community = CardStack{}
+	g.deadMoney = 0
+	for i := range g.Players {
+		player := g.Players[i]
+		player.bet = 0
+		player.contributed = 0
+		player.CardStack = CardStack{}
+		player.PlayerStatus = Waiting
+		if player.sittingOut {
+			player.PlayerStatus = SittingOut
+		}
+		player.IsDealer = false
+		player.hasActed = false
+		player.actedFacing = 0
+	}
+	g.waitForBigBlind()
+
+	// Set dealer position. With a dead button the dealer index points at the
+	// last player dealt in before the button, so the action still starts after it.
+	g.DealerIndex = g.playerAtSeat(g.buttonSeat)
+	if g.DealerIndex >= 0 && g.Players[g.DealerIndex].PlayerStatus != SittingOut {
+		g.Players[g.DealerIndex].IsDealer = true
+		g.log().Info("dealer chosen", slog.String("player", g.Players[g.DealerIndex].Name))
+	} else {
+		g.DealerIndex = g.playerAtSeat(g.previousSeatDealtIn(g.buttonSeat))
+		g.log().Info("dealer button is dead")
+	}
+	g.emit(HandStarted{Hand: g.HandNumber, Dealer: g.dealerName()})
 
-		case AllIn:
-			// Check if the player is already in a side pot
-			alreadyInSidePot := false
-			for _, pot := range g.Pots {
-				if containsPlayer(pot.Eligible, *player) {
-					alreadyInSidePot = true
-					break
-				}
+	// Deal two cards to each player, starting left of the button
+	for offset := 1; offset <= len(g.Players); offset++ {
+		player := g.Players[(g.DealerIndex+offset)%len(g.Players)]
+		if player.PlayerStatus == SittingOut {
+			continue
+		}
+		for range 2 {
+			if err := player.Deal(g.Deck); err != nil {
+				return err
 			}
+		}
+		g.emit(CardsDealt{Player: player.Name, Cards: append([]Card{}, player.cards...)})
+	}
 
-			if alreadyInSidePot && player.bet == 0 {
-				// If already in a side pot and no new bets, skip
-				continue
-			}
+	// Post the blinds, the small blind is skipped if its player has left or is not dealt in
+	if smallBlindIndex := g.playerAtSeat(g.smallBlindSeat); smallBlindIndex >= 0 && g.Players[smallBlindIndex].PlayerStatus != SittingOut {
+		posted := g.Players[smallBlindIndex].postBlind(g.smallBlind())
+		g.emit(BlindPosted{Player: g.Players[smallBlindIndex].Name, Kind: SmallBlind, Amount: posted})
+	}
 
-			// Determine if a side pot is needed
-			highestBet := 0
-			for _, p := range g.Players {
-				if p.bet > highestBet {
-					highestBet = p.bet
-				}
-			}
+	bigBlindIndex := g.playerAtSeat(g.bigBlindSeat)
+	posted := g.Players[bigBlindIndex].postBlind(g.BigBlind)
+	g.emit(BlindPosted{Player: g.Players[bigBlindIndex].Name, Kind: BigBlind, Amount: posted})
+	dead := g.postMissedBlinds()
+
+	// The big blind counts as the opening bet, even when posted short
+	g.highestBet = g.BigBlind
+	g.minRaise = g.betting().MinBet(g)
+	g.fullBet = g.BigBlind
+	g.raises = 1
+
+	// Initialize the main pot with the antes and dead blinds
+	antes := g.postAntes(bigBlindIndex)
+	dealtIn := slices.DeleteFunc(slices.Clone(g.Players), func(p *Player) bool {
+		return p.PlayerStatus == SittingOut
+	})
+	g.Pots = []Pot{{Amount: antes + dead, Eligible: playerIDs(dealtIn)}}
+	g.emit(PotCreated{Pot: 0, Amount: antes + dead, Eligible: playerNames(dealtIn)})
 
-			if player.bet < highestBet {
-				// Create a side pot for the excess bets
-				sidePot := Pot{Amount: 0, Eligible: []Player{}}
-				for j := range g.Players {
-					otherPlayer := &g.Players[j]
-					if otherPlayer.bet > player.bet {
-						excess := otherPlayer.bet - player.bet
-						sidePot.Amount += excess
-						otherPlayer.bet -= excess
-						sidePot.Eligible = append(sidePot.Eligible, *otherPlayer)
-					}
-				}
-				g.Pots = append(g.Pots, sidePot)
-			}
+	// Action starts with the player after the big blind
+	g.advanceTurn(bigBlindIndex)
+	g.audit(fmt.Sprintf("starting hand %d", g.HandNumber))
+	return nil
+}
 
-			// Add the All In player's bet to the main pot
-			g.Pots[0].Amount += player.bet
-			player.bet = 0
+// previousSeatDealtIn returns the last seat before the given one whose player is dealt into the hand
+func (g *Game) previousSeatDealtIn(seat int) int {
+	for offset := 1; offset <= g.seatCount(); offset++ {
+		previous := (seat - offset + g.seatCount()) % g.seatCount()
+		if index := g.playerAtSeat(previous); index >= 0 && g.Players[index].PlayerStatus != SittingOut {
+			return previous
 		}
 	}
+	return seat
+}
+
+// dealerName returns the name of the player on the button, empty if the button is dead
+func (g *Game) dealerName() string {
+	if g.DealerIndex >= 0 && g.Players[g.DealerIndex].IsDealer {
+		return g.Players[g.DealerIndex].Name
+	}
+	return ""
+}
 
-	// Handle the case where only one player remains active
-	activePlayers := 0
+// AddBetsToPots collects the current bets of the players and rebuilds the pots
+// from every player's contribution to the hand.
+//
+// Each distinct contribution of a player still in the hand caps a pot: the main
+// pot holds what everyone matched up to the smallest All In, and each side pot
+// the next layer above it. Folded money stays in the pots it was put into, but
+// folded players are never eligible to win them.
+func (g *Game) AddBetsToPots() {
+	for i := range g.Players {
+		g.Players[i].contributed += g.Players[i].bet
+		g.Players[i].bet = 0
+	}
+
+	// Every contribution of a player still in the hand is a pot level. A player
+	// who could only post an ante for the table still plays for the dead money.
+	levels := []int{}
 	for _, player := range g.Players {
-		if player.PlayerStatus != Folded {
-			activePlayers++
+		if player.inHand() && (player.contributed > 0 || g.deadMoney > 0) && !slices.Contains(levels, player.contributed) {
+			levels = append(levels, player.contributed)
 		}
 	}
-	if activePlayers == 1 {
-		fmt.Println("Only one player remains active. They win the pots.")
-		for _, pot := range g.Pots {
-			for i := range g.Players {
-				if g.Players[i].PlayerStatus != Folded {
-					g.Players[i].money += pot.Amount
-					pot.Amount = 0
-					break
-				}
+	slices.Sort(levels)
+
+	pots := []Pot{}
+	previous := 0
+	for _, level := range levels {
+		pot := Pot{Eligible: []int{}}
+		for _, player := range g.Players {
+			pot.Amount += min(player.contributed, level) - min(player.contributed, previous)
+			if player.inHand() && player.contributed >= level {
+				pot.Eligible = append(pot.Eligible, player.ID)
 			}
 		}
+		pots = append(pots, pot)
+		previous = level
+	}
+
+	// Money folded above the highest level can only go to the last pot
+	folded := 0
+	for _, player := range g.Players {
+		folded += max(player.contributed-previous, 0)
+	}
+	if len(pots) == 0 {
+		pots = append(pots, Pot{Eligible: []int{}})
+	}
+	pots[len(pots)-1].Amount += folded
+
+	// Antes posted for the whole table can be won by anyone still in the hand
+	pots[0].Amount += g.deadMoney
+
+	for j := len(g.Pots); j < len(pots); j++ {
+		g.emit(PotCreated{Pot: j, Amount: pots[j].Amount, Eligible: playerNames(g.EligiblePlayers(pots[j]))})
 	}
+	g.Pots = pots
 }
 
 // containsPlayer checks if a player is in the eligible list for a pot
-func containsPlayer(players []Player, player Player) bool {
-	for _, p := range players {
-		if p.Name == player.Name {
-			return true
+func containsPlayer(pot Pot, player *Player) bool {
+	return slices.Contains(pot.Eligible, player.ID)
+}
+
+// EligiblePlayers returns the players who can still win the pot: those it was
+// built for who are in the game and have not folded since
+func (g *Game) EligiblePlayers(pot Pot) []*Player {
+	players := []*Player{}
+	for _, player := range g.Players {
+		if player.inHand() && containsPlayer(pot, player) {
+			players = append(players, player)
 		}
 	}
-	return false
+	return players
 }
 
 // PreFlop transitions the game to the PreFlop state
-func (g *Game) PreFlop() {
+func (g *Game) PreFlop() error {
 	if g.GameStatus != StartGame {
-		fmt.Println("Game cannot transition to PreFlop. Current state:", g.GameStatus)
-		return
+		return g.wrongState("transition to PreFlop")
 	}
 
 	// Transition to PreFlop state
 	g.GameStatus = PreFlop
-	fmt.Println("Transitioning to PreFlop phase...")
+	g.logTransition()
 
 	// Add bets to pots if all players have called, folded, or gone all in
 	// There should be no bets at this point
 	//g.AddBetsToPots()
+
+	// The blinds may have been called or folded around already
+	err := g.advance()
+	g.audit(fmt.Sprintf("the transition to %v", PreFlop))
+	return err
 }
 
-func (g *Game) canTransition() bool {
+// canTransition checks that the betting round is complete: everyone has
+// acted and matched the highest bet, or cannot act any more
+func (g *Game) canTransition() error {
+	for i, player := range g.Players {
+		if g.needsToAct(i) {
+			return fmt.Errorf("%w: player %s has bet $%d of the highest bet of $%d and still has to act",
+				ErrBettingInProgress, player.Name, player.bet, g.highestBet)
+		}
+	}
+	return nil
+}
+
+// advance moves the hand on once the current betting round is complete.
+// The hand ends straight away when only one player remains, otherwise the next
+// street is dealt, running out the board when nobody is left to act.
+func (g *Game) advance() error {
+	for g.CurrentPlayer() == nil {
+		if g.playersInHand() == 1 {
+			g.endUncontested()
+			return nil
+		}
+		var err error
+		switch g.GameStatus {
+		case PreFlop:
+			err = g.Flop()
+		case Flop:
+			err = g.Turn()
+		case Turn:
+			err = g.River()
+		case River:
+			err = g.DetermineWinner()
+		default:
+			return nil
+		}
+		if err != nil || g.GameStatus == DetermineWinner {
+			return err
+		}
+	}
+	return nil
+}
+
+// playersInHand counts the players dealt in who have not folded
+func (g *Game) playersInHand() int {
+	count := 0
 	for _, player := range g.Players {
-		if player.PlayerStatus != Folded && player.PlayerStatus != Called && player.PlayerStatus != Checked {
-			fmt.Println("Not all players have called, checked, or folded.")
-			return false
+		if player.inHand() {
+			count++
 		}
-		if player.PlayerStatus != Folded && player.bet != g.highestBet {
-			fmt.Printf("Player %s's current bet: %d, Current highest bet: %d\n", player.Name, player.bet, g.highestBet)
-			fmt.Println("Not all active players have matched the highest bet.")
-			return false
+	}
+	return count
+}
+
+// endUncontested finishes the hand when everyone but one player has folded,
+// the remaining player wins the pots without a showdown
+func (g *Game) endUncontested() {
+	g.GameStatus = DetermineWinner
+	g.resetBettingRound()
+	g.AddBetsToPots()
+
+	for i := range g.Players {
+		if !g.Players[i].inHand() {
+			continue
+		}
+		for j := range g.Pots {
+			g.emit(PotAwarded{Pot: j, Amount: g.Pots[j].Amount, Winners: []string{g.Players[i].Name}, Shares: []int{g.Pots[j].Amount}})
+			g.Players[i].money += g.Pots[j].Amount
+			g.Pots[j].Amount = 0
 		}
+		break
 	}
-	return true
+	g.eliminatePlayers()
 }
 
-func (g *Game) Flop() {
+// Flop transitions the game to the Flop state and deals three community cards
+func (g *Game) Flop() error {
 	if g.GameStatus != PreFlop {
-		fmt.Println("Game cannot transition to Flop. Current state:", g.GameStatus)
-		return
+		return g.wrongState("transition to Flop")
 	}
 
-	if !g.canTransition() {
-		return
+	if err := g.canTransition(); err != nil {
+		return err
 	}
 
-	// Transition to Flop state
-	g.GameStatus = Flop
-	g.highestBet = 0 // Reset highest bet
-	fmt.Println("Transitioning to Flop phase...")
-
 	// Deal three cards to the Community
-	for i := 0; i < 3; i++ {
-		card, success := g.Deck.Pop()
-		if !success {
-			panic("Deck is empty! Cannot deal community cards.")
-		}
-		g.Community.Push(card)
+	if err := g.dealCommunity(3); err != nil {
+		return err
 	}
 
-	// Print the Community cards
-	fmt.Println("Community cards dealt:")
-	g.Community.ForEach(func(c Card) {
-		fmt.Println(c)
-	})
+	// Transition to Flop state
+	g.GameStatus = Flop
+	g.resetBettingRound()
+	g.logTransition()
+
+	// Announce the Community cards
+	g.emitStreet(3)
 
 	// Add bets to pots if all players have called, folded, or gone all in
 	g.AddBetsToPots()
+
+	// Action starts with the first active player after the dealer
+	g.advanceTurn(g.DealerIndex)
+	g.audit(fmt.Sprintf("the transition to %v", g.GameStatus))
+	return nil
 }
 
 // Turn transitions the game to the Turn state and deals one additional community card
-func (g *Game) Turn() {
+func (g *Game) Turn() error {
 	if g.GameStatus != Flop {
-		fmt.Println("Game cannot transition to Turn. Current state:", g.GameStatus)
-		return
+		return g.wrongState("transition to Turn")
 	}
 
-	if !g.canTransition() {
-		return
+	if err := g.canTransition(); err != nil {
+		return err
 	}
 
-	// Transition to Turn state
-	g.GameStatus = Turn
-	g.highestBet = 0 // Reset highest bet
-	fmt.Println("Transitioning to Turn phase...")
-
 	// Deal one card to the Community
-	card, success := g.Deck.Pop()
-	if !success {
-		panic("Deck is empty! Cannot deal community card.")
+	if err := g.dealCommunity(1); err != nil {
+		return err
 	}
-	g.Community.Push(card)
 
-	// Print the Community cards
-	fmt.Println("Community cards dealt:")
-	g.Community.ForEach(func(c Card) {
-		fmt.Println(c)
-	})
+	// Transition to Turn state
+	g.GameStatus = Turn
+	g.resetBettingRound()
+	g.logTransition()
+
+	// Announce the Community cards
+	g.emitStreet(1)
 
 	// Add bets to pots if all players have called, folded, or gone all in
 	g.AddBetsToPots()
+
+	// Action starts with the first active player after the dealer
+	g.advanceTurn(g.DealerIndex)
+	g.audit(fmt.Sprintf("the transition to %v", g.GameStatus))
+	return nil
 }
 
 // River transitions the game to the River state and deals one final community card
-func (g *Game) River() {
+func (g *Game) River() error {
 	if g.GameStatus != Turn {
-		fmt.Println("Game cannot transition to River. Current state:", g.GameStatus)
-		return
+		return g.wrongState("transition to River")
 	}
 
-	if !g.canTransition() {
-		return
+	if err := g.canTransition(); err != nil {
+		return err
 	}
 
-	// Transition to River state
-	g.GameStatus = River
-	g.highestBet = 0 // Reset highest bet
-	fmt.Println("Transitioning to River phase...")
-
 	// Deal one card to the Community
-	card, success := g.Deck.Pop()
-	if !success {
-		panic("Deck is empty! Cannot deal community card.")
+	if err := g.dealCommunity(1); err != nil {
+		return err
 	}
-	g.Community.Push(card)
 
-	// Print the Community cards
-	fmt.Println("Community cards dealt:")
-	g.Community.ForEach(func(c Card) {
-		fmt.Println(c)
-	})
+	// Transition to River state
+	g.GameStatus = River
+	g.resetBettingRound()
+	g.logTransition()
+
+	// Announce the Community cards
+	g.emitStreet(1)
 
 	// Add bets to pots if all players have called, folded, or gone all in
 	g.AddBetsToPots()
+
+	// Action starts with the first active player after the dealer
+	g.advanceTurn(g.DealerIndex)
+	g.audit(fmt.Sprintf("the transition to %v", g.GameStatus))
+	return nil
+}
+
+// dealCommunity deals n cards from the deck to the Community, leaving both
+// untouched if the deck does not hold enough cards
+func (g *Game) dealCommunity(n int) error {
+	if g.Deck.Count() < n {
+		return fmt.Errorf("%w: cannot deal %d community cards from %d remaining", ErrDeckEmpty, n, g.Deck.Count())
+	}
+	for i := 0; i < n; i++ {
+		card, _ := g.Deck.Pop()
+		g.Community.Push(card)
+	}
+	return nil
+}
+
+// logTransition logs that the game has moved to its current state
+func (g *Game) logTransition() {
+	g.log().Info("transitioning to the next phase", slog.String("state", g.GameStatus.String()))
+}
+
+// emitStreet announces the last n community cards dealt
+func (g *Game) emitStreet(n int) {
+	board := append([]Card{}, g.Community.cards...)
+	g.emit(StreetDealt{Street: g.GameStatus, Cards: board[len(board)-n:], Board: board})
 }
 
 // DetermineWinner transitions the game to the DetermineWinner state, evaluates player hands, and announces the winner(s)
-func (g *Game) DetermineWinner() {
+func (g *Game) DetermineWinner() error {
 	if g.GameStatus != River {
-		fmt.Println("Game cannot transition to DetermineWinner. Current state:", g.GameStatus)
-		return
+		return g.wrongState("transition to DetermineWinner")
 	}
 
-	if !g.canTransition() {
-		return
+	if err := g.canTransition(); err != nil {
+		return err
 	}
 
 	// Transition to DetermineWinner state
 	g.GameStatus = DetermineWinner
-	g.highestBet = 0 // Reset highest bet
-	fmt.Println("Determining the winner(s)...")
+	g.resetBettingRound()
+	g.logTransition()
+
+	// Collect the bets from the river
+	g.AddBetsToPots()
 
 	// Evaluate hands and determine winners
 	winners := EvaluateGame(g.Players, g.Community.cards)
 
-	// Announce winners
-	if len(winners) == 1 {
-		fmt.Printf("The winner is %s!\n", winners[0].Name)
-	} else {
-		fmt.Print("The winners are: ")
-		for i, winner := range winners {
-			if i > 0 {
-				fmt.Print(", ")
-			}
-			fmt.Print(winner.Name)
+	// Announce winners along with the hands shown down
+	showdown := Showdown{Winners: playerNames(winners)}
+	for _, player := range g.Players {
+		if player.inHand() {
+			showdown.Hands = append(showdown.Hands, ShownHand{
+				Player: player.Name,
+				Cards:  append([]Card{}, player.cards...),
+				Hand:   BestHand(getCombinedHand(player, g.Community.cards)),
+			})
 		}
-		fmt.Println("!")
 	}
+	g.emit(showdown)
 
 	// Distribute pot winnings to winners
-	for _, pot := range g.Pots {
-		winners := EvaluateGame(pot.Eligible, g.Community.cards)
-		winnings := pot.Amount / len(winners)
-		for _, winner := range winners {
-			for i := range g.Players {
-				if g.Players[i].Name == winner.Name {
-					g.Players[i].money += winnings
-					break
-				}
-			}
+	for j, pot := range g.Pots {
+		winners := EvaluateGame(g.EligiblePlayers(pot), g.Community.cards)
+		shares := g.splitPot(pot.Amount, winners)
+		g.emit(PotAwarded{Pot: j, Amount: pot.Amount, Winners: playerNames(winners), Shares: shares})
+		for k, winner := range winners {
+			winner.money += shares[k]
 		}
+		g.Pots[j].Amount = 0
 	}
 
+	g.eliminatePlayers()
+	g.audit(fmt.Sprintf("the transition to %v", g.GameStatus))
+	return nil
+}
+
+// eliminatePlayers removes players with zero balance and logs the final balances
+func (g *Game) eliminatePlayers() {
 	// Eliminate players with zero balance
 	eliminatedPlayers := []string{}
 	for i := 0; i < len(g.Players); {
 		if g.Players[i].money <= 0 {
 			eliminatedPlayers = append(eliminatedPlayers, g.Players[i].Name)
-			g.Players = append(g.Players[:i], g.Players[i+1:]...)
+			g.removePlayer(i)
 		} else {
 			i++
 		}
 	}
 
 	// Announce eliminated players
-	if len(eliminatedPlayers) > 0 {
-		fmt.Println("The following players have been eliminated:")
-		for _, name := range eliminatedPlayers {
-			fmt.Println(name)
-		}
+	for _, name := range eliminatedPlayers {
+		g.emit(PlayerEliminated{Player: name})
 	}
 
-	// Print final player balances
-	fmt.Println("Final player balances:")
+	// Log final player balances
 	for _, player := range g.Players {
-		fmt.Printf("%s: $%d\n", player.Name, player.money)
+		g.log().Info("final player balance", slog.String("player", player.Name), slog.Int("money", player.money))
+	}
+}
+
+// removePlayer takes the player at index i out of the game between hands.
+// DealerIndex stays on the same player, or moves to the player before the one
+// removed, so it remains a valid index.
+func (g *Game) removePlayer(i int) {
+	g.Players = append(g.Players[:i], g.Players[i+1:]...)
+	if g.DealerIndex >= i {
+		g.DealerIndex--
 	}
 }
 
-func (g *Game) PlayerRaise(playerIndex, amount int) {
-	if g.Players[playerIndex].Raise(amount, g) {
-		// highestBet is updated within Raise
+// PlayerRaise raises by amount on behalf of the player at playerIndex
+func (g *Game) PlayerRaise(playerIndex, amount int) error {
+	if playerIndex < 0 || playerIndex >= len(g.Players) {
+		return fmt.Errorf("%w: no player at index %d", ErrPlayerNotFound, playerIndex)
 	}
+	// highestBet is updated within Raise
+	return g.Players[playerIndex].Raise(amount, g)
+}
+
+// PlayerByID returns the player with the given ID, or nil if they are not in the game
+func (g *Game) PlayerByID(id int) *Player {
+	for i := range g.Players {
+		if g.Players[i].ID == id {
+			return g.Players[i]
+		}
+	}
+	return nil
 }
 
-// getPlayer retrieves a pointer to a player by name or index.
-// If both name and index are provided, index takes precedence.
-// Returns nil if the player is not found.
+// getPlayer retrieves a pointer to a player by name or index, names being
+// unique within the game. If both name and index are provided, index takes
+// precedence. Returns nil if the player is not found.
 func (g *Game) getPlayer(name string, index int) *Player {
 	if index >= 0 && index < len(g.Players) {
-		return &g.Players[index]
+		return g.Players[index]
 	}
 	for i := range g.Players {
 		if g.Players[i].Name == name {
-			return &g.Players[i]
+			return g.Players[i]
 		}
 	}
 	return nil
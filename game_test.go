@@ -1,6 +1,8 @@
 package poker
 
 import (
+	"errors"
+	"reflect"
 	"testing"
 )
 
@@ -26,6 +28,43 @@ func TestAddPlayer(t *testing.T) {
 	}
 }
 
+func TestAddPlayerDuplicate(t *testing.T) {
+	game := NewGame(1000, 50)
+	game.AddPlayer("Alex")
+	if err := game.AddPlayer("Alex"); !errors.Is(err, ErrDuplicatePlayer) {
+		t.Errorf("Expected a second Alex to fail with %v, got %v", ErrDuplicatePlayer, err)
+	}
+	if err := game.SitDown("Alex", 5, 1000); !errors.Is(err, ErrDuplicatePlayer) {
+		t.Errorf("Expected a second Alex to fail with %v, got %v", ErrDuplicatePlayer, err)
+	}
+	if len(game.Players) != 1 {
+		t.Errorf("Expected 1 player, got %d", len(game.Players))
+	}
+}
+
+func TestPlayerIDs(t *testing.T) {
+	game := NewGame(1000, 50)
+	game.AddPlayer("Alice")
+	game.AddPlayer("Bob")
+	alice, bob := game.Players[0].ID, game.Players[1].ID
+	if alice == 0 || alice == bob {
+		t.Fatalf("Expected unique IDs, got %d and %d", alice, bob)
+	}
+	if player := game.PlayerByID(bob); player == nil || player.Name != "Bob" {
+		t.Errorf("Expected to find Bob by ID, got %+v", player)
+	}
+
+	// A player who leaves and comes back is a new player
+	game.Leave("Alice")
+	game.AddPlayer("Alice")
+	if player := game.getPlayer("Alice", -1); player.ID == alice || player.ID == bob {
+		t.Errorf("Expected Alice to get a new ID, got %d", player.ID)
+	}
+	if game.PlayerByID(alice) != nil {
+		t.Errorf("Expected the old ID to be gone, got %+v", game.PlayerByID(alice))
+	}
+}
+
 func TestStartGame(t *testing.T) {
 	game := NewGame(1000, 50)
 
@@ -96,13 +135,9 @@ func TestTurn(t *testing.T) {
 	game.StartGame()
 	game.PreFlop()
 	p1.Call(game)  // Call the big blind
-	p2.Check(game) // Check the big blind
-	game.Flop()
-	p1.Check(game) // Check the flop
-	p2.Check(game) // Check the flop
-
-	// Turn phase
-	game.Turn()
+	p2.Check(game) // Check the big blind, the flop is dealt
+	p2.Check(game) // Big blind acts first after the flop
+	p1.Check(game) // Check the flop, the turn is dealt
 
 	// Assertions
 	if game.GameStatus != Turn {
@@ -138,14 +173,11 @@ func TestRiver(t *testing.T) {
 	game.StartGame()
 	game.PreFlop()
 	p1.Call(game)  // Call the big blind
-	p2.Check(game) // Check the big blind
-	game.Flop()
-	p1.Check(game) // Check the flop
-	p2.Check(game) // Check the flop
-	game.Turn()
-
-	// River phase
-	game.River()
+	p2.Check(game) // Check the big blind, the flop is dealt
+	p2.Check(game) // Big blind acts first after the flop
+	p1.Check(game) // Check the flop, the turn is dealt
+	p2.Check(game) // Check the turn
+	p1.Check(game) // Check the turn, the river is dealt
 
 	// Assertions
 	if game.GameStatus != River {
@@ -189,7 +221,6 @@ func TestAddBetsToPots(t *testing.T) {
 	p3.Raise(50, game) // Raise to $100
 	p1.Call(game)      // Call the raise
 	p2.Call(game)      // Call the raise
-	p3.Check(game)     // Check the raise
 
 	// Debug
 	for i, player := range game.Players {
@@ -222,6 +253,130 @@ func TestAddBetsToPots(t *testing.T) {
 	}
 }
 
+func TestSidePots(t *testing.T) {
+	game := newStartedGame(1000, 50, "Alice", "Bob", "Charlie")
+	p1 := game.getPlayer("Alice", 0)   // Dealer
+	p2 := game.getPlayer("Bob", 1)     // Small blind
+	p3 := game.getPlayer("Charlie", 2) // Big blind
+	p1.money = 300
+	p2.money = 575
+	recorder := &eventRecorder{}
+	game.Subscribe(recorder)
+
+	p1.AllIn(game) // All In for $300
+	p2.AllIn(game) // All In for $600
+	p3.Call(game)  // Call $600, the board is run out
+
+	if game.GameStatus != DetermineWinner {
+		t.Fatalf("Expected game status to be DetermineWinner, got %v", game.GameStatus)
+	}
+
+	expected := []struct {
+		amount   int
+		eligible []*Player
+	}{
+		{900, []*Player{p1, p2, p3}},
+		{600, []*Player{p2, p3}},
+	}
+	if len(game.Pots) != len(expected) {
+		t.Fatalf("Expected %d pots, got %d", len(expected), len(game.Pots))
+	}
+	awarded := map[int]int{}
+	for _, e := range recorder.events {
+		if e, ok := e.(PotAwarded); ok {
+			awarded[e.Pot] = e.Amount
+		}
+	}
+	for j, pot := range game.Pots {
+		if awarded[j] != expected[j].amount || !reflect.DeepEqual(pot.Eligible, playerIDs(expected[j].eligible)) {
+			t.Errorf("Expected pot %d to award %d to one of %v, got %d for %v", j,
+				expected[j].amount, playerNames(expected[j].eligible), awarded[j], pot.Eligible)
+		}
+	}
+
+	total := 0
+	for _, player := range game.Players {
+		total += player.money
+	}
+	if total != 1900 {
+		t.Errorf("Expected the players to hold 1900 in total, got %d", total)
+	}
+}
+
+func TestSidePotsFoldedMoney(t *testing.T) {
+	game := newStartedGame(1000, 50, "Alice", "Bob", "Charlie", "Dave")
+	p1 := game.getPlayer("Alice", 0)   // Dealer
+	p2 := game.getPlayer("Bob", 1)     // Small blind
+	p3 := game.getPlayer("Charlie", 2) // Big blind
+	p4 := game.getPlayer("Dave", 3)
+	p1.money = 200
+	recorder := &eventRecorder{}
+	game.Subscribe(recorder)
+
+	p4.Raise(100, game) // Raise to $100
+	p1.AllIn(game)      // All In for $200
+	p2.Fold(game)       // Folds the small blind
+	p3.Raise(350, game) // Raise to $400
+	p4.Fold(game)       // Folds $100, the board is run out
+
+	if game.GameStatus != DetermineWinner {
+		t.Fatalf("Expected game status to be DetermineWinner, got %v", game.GameStatus)
+	}
+
+	// The main pot holds everything matched up to Alice's All In, the rest is Charlie's
+	expected := []struct {
+		amount   int
+		eligible []*Player
+	}{
+		{525, []*Player{p1, p3}},
+		{200, []*Player{p3}},
+	}
+	if len(game.Pots) != len(expected) {
+		t.Fatalf("Expected %d pots, got %d", len(expected), len(game.Pots))
+	}
+	awarded := map[int]int{}
+	for _, e := range recorder.events {
+		if e, ok := e.(PotAwarded); ok {
+			awarded[e.Pot] = e.Amount
+		}
+	}
+	for j, pot := range game.Pots {
+		if awarded[j] != expected[j].amount || !reflect.DeepEqual(pot.Eligible, playerIDs(expected[j].eligible)) {
+			t.Errorf("Expected pot %d to award %d to one of %v, got %d for %v", j,
+				expected[j].amount, playerNames(expected[j].eligible), awarded[j], pot.Eligible)
+		}
+	}
+}
+
+func TestEligiblePlayersFollowFolds(t *testing.T) {
+	game := newStartedGame(1000, 50, "Alice", "Bob", "Charlie")
+	pot := game.Pots[0]
+	game.getPlayer("Alice", 0).Fold(game)
+
+	// The pot still lists Alice, but she can no longer win it
+	if len(pot.Eligible) != 3 {
+		t.Fatalf("Expected the pot to be built for 3 players, got %v", pot.Eligible)
+	}
+	if names := playerNames(game.EligiblePlayers(pot)); !reflect.DeepEqual(names, []string{"Bob", "Charlie"}) {
+		t.Errorf("Expected Bob and Charlie to be eligible, got %v", names)
+	}
+}
+
+func TestPlayersKeptByPointer(t *testing.T) {
+	game := newStartedGame(1000, 50, "Bob", "Charlie", "Dave")
+	charlie := game.getPlayer("Charlie", -1)
+	foldAround(game)
+
+	// Bob leaves, Alice takes his seat and Dave busts, Charlie's player stays the same
+	game.Leave("Bob")
+	game.SitDown("Alice", 0, 1000)
+	bust(game, "Dave")
+	game.NextHand()
+	if game.Players[0].Name != "Alice" || game.getPlayer("Charlie", -1) != charlie {
+		t.Errorf("Expected Charlie's player to survive the seats changing, got %v", playerNames(game.Players))
+	}
+}
+
 func TestFlop(t *testing.T) {
 	// Setup
 	game := NewGame(1000, 50)
@@ -241,10 +396,9 @@ func TestFlop(t *testing.T) {
 
 	p1.Call(game)  // Call the big blind
 	p2.Check(game) // Check the big blind
-	game.PreFlop()
 
-	// Flop phase
-	game.Flop()
+	// The betting round is complete, so the flop is dealt
+	game.PreFlop()
 
 	// Assertions
 	if game.GameStatus != Flop {
@@ -283,35 +437,37 @@ func TestPlayerElimination(t *testing.T) {
 	p2.Call(game)  // Call the small blind
 	p3.Check(game) // Check the big blind
 
-	game.PreFlop()
-	p2.Check(game) // Check the big blind
-	p3.Check(game) // Check the big blind
-	p1.Check(game) // Check the big blind
+	game.PreFlop() // The flop is dealt
 
-	game.Flop()
-	p2.Check(game) // Check the big blind
-	p3.Check(game) // Check the big blind
-	p1.Check(game) // Check the big blind
-
-	game.Turn()
-	p2.Check(game) // Check the big blind
-	p3.Check(game) // Check the big blind
-	p1.Check(game) // Check the big blind
+	p2.Check(game) // Check the flop
+	p3.Check(game) // Check the flop
+	p1.Check(game) // Check the flop, the turn is dealt
 
-	game.River()
-	p2.Check(game) // Check the big blind
-	p3.Check(game) // Check the big blind
-	p1.Check(game) // Check the big blind
+	p2.Check(game) // Check the turn
+	p3.Check(game) // Check the turn
+	p1.Check(game) // Check the turn, the river is dealt
 
 	// Simulate a game where Bob and Charlie lose all their money
 	game.Players[0].money = 50 // Alice has some money left
 	game.Players[1].money = 0  // Bob is out of money
 	game.Players[2].money = 0  // Charlie is out of money
 
-	// TODO : rig the game so that Alice wins
-
-	// Call DetermineWinner to trigger elimination logic
-	game.DetermineWinner()
+	// Rig the game so that Alice wins
+	game.Community = CardStack{[]Card{
+		{Spades, Two}, {Hearts, Seven}, {Diamonds, Nine}, {Clubs, Jack}, {Spades, King},
+	}}
+	game.Players[0].CardStack = CardStack{[]Card{{Hearts, Ace}, {Clubs, Ace}}}
+	game.Players[1].CardStack = CardStack{[]Card{{Hearts, Three}, {Clubs, Four}}}
+	game.Players[2].CardStack = CardStack{[]Card{{Hearts, Five}, {Clubs, Six}}}
+
+	// Checking the river determines the winner and triggers the elimination logic
+	p2.Check(game)
+	p3.Check(game)
+	p1.Check(game)
+
+	if game.GameStatus != DetermineWinner {
+		t.Fatalf("Expected game status to be DetermineWinner, got %v", game.GameStatus)
+	}
 
 	// Check that only Alice remains in the game
 	if len(game.Players) != 1 {
@@ -322,3 +478,292 @@ func TestPlayerElimination(t *testing.T) {
 		t.Fatalf("Expected remaining player to be Alice, got %s", game.Players[0].Name)
 	}
 }
+
+func TestRaiseClosesBettingRound(t *testing.T) {
+	game := newStartedGame(1000, 50, "Alice", "Bob")
+	p1 := game.getPlayer("Alice", 0)
+	p2 := game.getPlayer("Bob", 1)
+
+	p1.Raise(125, game) // Raise to $150
+	p2.Call(game)       // Call the raise, the flop is dealt
+
+	if game.GameStatus != Flop {
+		t.Errorf("Expected game status to be Flop, got %v", game.GameStatus)
+	}
+	if game.CurrentPlayer() != p2 {
+		t.Errorf("Expected Bob to act first after the flop, got %v", game.CurrentPlayer())
+	}
+	if game.Pots[0].Amount != 300 {
+		t.Errorf("Expected pot value to be 300, got %d", game.Pots[0].Amount)
+	}
+}
+
+func TestAllInRunsOutBoard(t *testing.T) {
+	game := newStartedGame(1000, 50, "Alice", "Bob")
+	game.getPlayer("Alice", 0).AllIn(game)
+	game.getPlayer("Bob", 1).Call(game) // Calling puts Bob All In as well
+
+	if game.GameStatus != DetermineWinner {
+		t.Fatalf("Expected game status to be DetermineWinner, got %v", game.GameStatus)
+	}
+	if game.Community.Count() != 5 {
+		t.Errorf("Expected 5 community cards, got %d", game.Community.Count())
+	}
+
+	total := 0
+	for _, player := range game.Players {
+		total += player.money
+	}
+	if total != 2000 {
+		t.Errorf("Expected the players to hold 2000 in total, got %d", total)
+	}
+}
+
+func TestFoldEndsHand(t *testing.T) {
+	game := newStartedGame(1000, 50, "Alice", "Bob", "Charlie")
+	game.getPlayer("Alice", 0).Fold(game)
+	game.getPlayer("Bob", 1).Fold(game)
+
+	if game.GameStatus != DetermineWinner {
+		t.Fatalf("Expected game status to be DetermineWinner, got %v", game.GameStatus)
+	}
+	if game.Community.Count() != 0 {
+		t.Errorf("Expected no community cards, got %d", game.Community.Count())
+	}
+
+	expected := []int{1000, 975, 1025}
+	for i, player := range game.Players {
+		if player.money != expected[i] {
+			t.Errorf("Expected %s to have %d, got %d", player.Name, expected[i], player.money)
+		}
+	}
+}
+
+func TestGameErrors(t *testing.T) {
+	game := NewGame(1000, 50)
+	game.AddPlayer("Alice")
+
+	if err := game.StartGame(); !errors.Is(err, ErrWrongState) {
+		t.Errorf("Expected starting before initialising to fail with %v, got %v", ErrWrongState, err)
+	}
+	game.Initialise()
+	if err := game.Initialise(); !errors.Is(err, ErrWrongState) {
+		t.Errorf("Expected initialising twice to fail with %v, got %v", ErrWrongState, err)
+	}
+	game.Players[0].IsReady = true
+	if err := game.StartGame(); !errors.Is(err, ErrNotEnoughPlayers) {
+		t.Errorf("Expected starting alone to fail with %v, got %v", ErrNotEnoughPlayers, err)
+	}
+	game.AddPlayer("Bob")
+	if err := game.StartGame(); !errors.Is(err, ErrPlayerNotReady) {
+		t.Errorf("Expected starting with Bob not ready to fail with %v, got %v", ErrPlayerNotReady, err)
+	}
+	game.Players[1].IsReady = true
+	if err := game.StartGame(); err != nil {
+		t.Fatalf("Expected the game to start, got %v", err)
+	}
+	if err := game.Flop(); !errors.Is(err, ErrWrongState) {
+		t.Errorf("Expected the flop before the preflop to fail with %v, got %v", ErrWrongState, err)
+	}
+	if err := game.PreFlop(); err != nil {
+		t.Fatalf("Expected the preflop to start, got %v", err)
+	}
+	if err := game.Flop(); !errors.Is(err, ErrBettingInProgress) {
+		t.Errorf("Expected the flop during betting to fail with %v, got %v", ErrBettingInProgress, err)
+	}
+	if err := game.PlayerRaise(5, 100); !errors.Is(err, ErrPlayerNotFound) {
+		t.Errorf("Expected raising for a missing player to fail with %v, got %v", ErrPlayerNotFound, err)
+	}
+}
+
+func TestDealCommunityDeckEmpty(t *testing.T) {
+	game := newStartedGame(1000, 50, "Alice", "Bob")
+	game.Deck = &Deck{CardStack{[]Card{{Clubs, King}, {Clubs, Queen}}}}
+	game.getPlayer("Alice", 0).Call(game)
+
+	// The flop cannot be dealt, so the game stays in PreFlop
+	if err := game.getPlayer("Bob", 1).Check(game); !errors.Is(err, ErrDeckEmpty) {
+		t.Errorf("Expected %v, got %v", ErrDeckEmpty, err)
+	}
+	if game.GameStatus != PreFlop || game.Community.Count() != 0 {
+		t.Errorf("Expected the game to remain in PreFlop without community cards, got %v with %d", game.GameStatus, game.Community.Count())
+	}
+}
+
+func TestNextHand(t *testing.T) {
+	game := newStartedGame(1000, 50, "Alice", "Bob", "Charlie")
+	game.getPlayer("Alice", 0).Fold(game)
+	game.getPlayer("Bob", 1).Fold(game)
+
+	if err := game.NextHand(); err != nil {
+		t.Fatalf("Expected the next hand to start, got %v", err)
+	}
+	if game.HandNumber != 2 {
+		t.Errorf("Expected hand number 2, got %d", game.HandNumber)
+	}
+	if game.DealerIndex != 1 || !game.Players[1].IsDealer || game.Players[0].IsDealer {
+		t.Errorf("Expected the button to move to Bob, got dealer index %d", game.DealerIndex)
+	}
+	if game.Deck.Count() != 46 {
+		t.Errorf("Expected 46 cards left in the deck, got %d", game.Deck.Count())
+	}
+	if game.Community.Count() != 0 {
+		t.Errorf("Expected no community cards, got %d", game.Community.Count())
+	}
+
+	// Charlie posts the small blind and Alice the big blind
+	expected := []struct {
+		bet, money int
+		status     PlayerStatus
+	}{
+		{50, 950, Waiting},
+		{0, 975, Thinking},
+		{25, 1000, Waiting},
+	}
+	for i, player := range game.Players {
+		if player.Count() != 2 {
+			t.Errorf("Expected %s to hold 2 cards, got %d", player.Name, player.Count())
+		}
+		if player.bet != expected[i].bet || player.money != expected[i].money || player.PlayerStatus != expected[i].status {
+			t.Errorf("Expected %s to have bet %d with %d left and status %v, got %d, %d and %v", player.Name,
+				expected[i].bet, expected[i].money, expected[i].status, player.bet, player.money, player.PlayerStatus)
+		}
+	}
+
+	if err := game.PreFlop(); err != nil {
+		t.Fatalf("Expected the preflop to start, got %v", err)
+	}
+	if game.CurrentPlayer() != game.Players[1] {
+		t.Errorf("Expected Bob to act first, got %v", game.CurrentPlayer())
+	}
+}
+
+func TestNextHandDeadButton(t *testing.T) {
+	game := newStartedGame(1000, 50, "Alice", "Bob", "Charlie", "Dave")
+	game.Players[1].money = 0 // Bob loses his small blind and busts
+	game.getPlayer("Dave", 3).Fold(game)
+	game.getPlayer("Alice", 0).Fold(game)
+	game.getPlayer("Bob", 1).Fold(game)
+
+	if len(game.Players) != 3 {
+		t.Fatalf("Expected 3 players remaining, got %d", len(game.Players))
+	}
+	if err := game.NextHand(); err != nil {
+		t.Fatalf("Expected the next hand to start, got %v", err)
+	}
+
+	// Bob's empty seat keeps the button, Charlie posts the small blind and Dave the big blind
+	for _, player := range game.Players {
+		if player.IsDealer {
+			t.Errorf("Expected a dead button, got %s as the dealer", player.Name)
+		}
+	}
+	if game.DealerIndex != 0 {
+		t.Errorf("Expected the dealer index to stay before the empty seat, got %d", game.DealerIndex)
+	}
+	if bet := game.getPlayer("Charlie", 1).bet; bet != 25 {
+		t.Errorf("Expected Charlie to post the small blind, got %d", bet)
+	}
+	if bet := game.getPlayer("Dave", 2).bet; bet != 50 {
+		t.Errorf("Expected Dave to post the big blind, got %d", bet)
+	}
+
+	game.PreFlop()
+	if game.CurrentPlayer() != game.Players[0] {
+		t.Errorf("Expected Alice to act first, got %v", game.CurrentPlayer())
+	}
+}
+
+func TestNextHandErrors(t *testing.T) {
+	game := newStartedGame(1000, 50, "Alice", "Bob")
+	if err := game.NextHand(); !errors.Is(err, ErrWrongState) {
+		t.Errorf("Expected starting the next hand mid-hand to fail with %v, got %v", ErrWrongState, err)
+	}
+
+	game.Players[0].money = 0 // Alice busts after folding her small blind
+	game.getPlayer("Alice", 0).Fold(game)
+	if err := game.NextHand(); !errors.Is(err, ErrNotEnoughPlayers) {
+		t.Errorf("Expected starting the next hand alone to fail with %v, got %v", ErrNotEnoughPlayers, err)
+	}
+}
+
+func TestHeadsUpActionOrder(t *testing.T) {
+	game := newStartedGame(1000, 50, "Alice", "Bob")
+	alice := game.getPlayer("Alice", 0) // Dealer and small blind
+	bob := game.getPlayer("Bob", 1)     // Big blind
+
+	if !alice.IsDealer || alice.bet != 25 || bob.bet != 50 {
+		t.Fatalf("Expected the dealer to post the small blind, got bets %d and %d", alice.bet, bob.bet)
+	}
+
+	// The dealer acts first preflop
+	if game.CurrentPlayer() != alice {
+		t.Fatalf("Expected Alice to act first preflop, got %v", game.CurrentPlayer())
+	}
+	alice.Call(game)
+	bob.Check(game)
+
+	// And last on every street after the flop
+	for _, street := range []GameStatus{Flop, Turn, River} {
+		if game.GameStatus != street {
+			t.Fatalf("Expected game status to be %v, got %v", street, game.GameStatus)
+		}
+		if game.CurrentPlayer() != bob {
+			t.Fatalf("Expected Bob to act first on the %v, got %v", street, game.CurrentPlayer())
+		}
+		bob.Check(game)
+		if game.CurrentPlayer() != alice {
+			t.Fatalf("Expected Alice to act last on the %v, got %v", street, game.CurrentPlayer())
+		}
+		alice.Check(game)
+	}
+}
+
+func TestHeadsUpAlternates(t *testing.T) {
+	game := newStartedGame(1000, 50, "Alice", "Bob")
+	for hand := 1; hand <= 4; hand++ {
+		dealer, other := hand%2, (hand+1)%2
+		if !game.Players[other].IsDealer || game.Players[other].bet != 25 || game.Players[dealer].bet != 50 {
+			t.Fatalf("Expected %s to deal and post the small blind in hand %d", game.Players[other].Name, hand)
+		}
+		if game.CurrentPlayer() != game.Players[other] {
+			t.Fatalf("Expected the dealer to act first in hand %d, got %v", hand, game.CurrentPlayer())
+		}
+		game.CurrentPlayer().Fold(game)
+		game.NextHand()
+		game.PreFlop()
+	}
+}
+
+func TestHeadsUpFromThreeHanded(t *testing.T) {
+	// Alice deals, Bob posts the small blind and Charlie the big blind
+	tests := []struct {
+		busted           string
+		dealer, bigBlind string
+	}{
+		{busted: "Alice", dealer: "Charlie", bigBlind: "Bob"},
+		{busted: "Bob", dealer: "Charlie", bigBlind: "Alice"},
+		{busted: "Charlie", dealer: "Bob", bigBlind: "Alice"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.busted, func(t *testing.T) {
+			game := newStartedGame(1000, 50, "Alice", "Bob", "Charlie")
+			game.getPlayer("Alice", 0).Fold(game)
+			game.getPlayer("Bob", 1).Fold(game)
+			game.getPlayer(tt.busted, -1).money = 0
+			game.eliminatePlayers()
+
+			if err := game.NextHand(); err != nil {
+				t.Fatalf("Expected the next hand to start, got %v", err)
+			}
+			dealer := game.Players[game.DealerIndex]
+			if dealer.Name != tt.dealer || !dealer.IsDealer || dealer.bet != 25 {
+				t.Errorf("Expected %s to deal and post the small blind, got %s with a bet of %d", tt.dealer, dealer.Name, dealer.bet)
+			}
+			bigBlind := game.getPlayer(tt.bigBlind, -1)
+			if bigBlind.bet != 50 {
+				t.Errorf("Expected %s to post the big blind, got %d", tt.bigBlind, bigBlind.bet)
+			}
+		})
+	}
+}
@@ -0,0 +1,411 @@
+package poker
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"strings"
+)
+
+// HandHistory is a listener that writes every hand of a game, once it is over,
+// as a text hand history in the PokerStars format, which hand tracking tools
+// can import. It is created for the game it listens to, which it reads the
+// table from as each hand starts:
+//
+//	g.Subscribe(NewHandHistory(f, g))
+//
+// The format has no label for betting structures other than NoLimit, PotLimit
+// and FixedLimit, so the hands of a SpreadLimit game, say, are left out.
+// Actions taken back with Game.Undo are left out. A hand is written as soon as
+// its pots are awarded, so taking back an action after that writes the hand
+// again once it is over.
+type HandHistory struct {
+	Hero  string // Player whose hole cards are written, every player's when empty
+	Clock Clock  // Tells the time each hand starts, the system clock when nil
+
+	w     io.Writer
+	g     *Game
+	table *historyTable // The table of the current hand, or of the last one once it is over
+}
+
+// historyTable is the table as a hand starts, with the events of the hand so far
+type historyTable struct {
+	header  string
+	seats   []historySeat
+	events  []Event
+	written bool // Whether the hand is over and written, until an action is taken back
+}
+
+// historySeat is a player seated as a hand starts
+type historySeat struct {
+	seat       int
+	name       string
+	money      int
+	roles      string // Button and blinds, e.g. " (big blind)"
+	sittingOut bool
+}
+
+// NewHandHistory creates a hand history of the game writing to w, e.g. a file
+func NewHandHistory(w io.Writer, g *Game) *HandHistory {
+	return &HandHistory{w: w, g: g}
+}
+
+// HandleEvent collects the events of the hand, and writes the hand once its
+// pots are awarded. Once it is written, only an action taken back reopens it.
+func (h *HandHistory) HandleEvent(e Event) {
+	if started, ok := e.(HandStarted); ok {
+		h.table = h.startTable(started)
+		return
+	}
+	if _, undone := e.(ActionUndone); h.table == nil || h.table.written && !undone {
+		return
+	}
+
+	switch e := e.(type) {
+	case ActionUndone:
+		// Drop the action taken back along with everything that followed it
+		for i := len(h.table.events) - 1; i >= 0; i-- {
+			if isAction(h.table.events[i]) {
+				h.table.events = h.table.events[:i]
+				break
+			}
+		}
+		h.table.written = false
+	case PotAwarded:
+		h.table.events = append(h.table.events, e)
+		if e.Pot == len(h.g.Pots)-1 {
+			fmt.Fprint(h.w, h.table.write(h.Hero))
+			h.table.written = true
+		}
+	default:
+		h.table.events = append(h.table.events, e)
+	}
+}
+
+// isAction reports whether the event is a player's action, which Game.Undo takes back
+func isAction(e Event) bool {
+	switch e := e.(type) {
+	case ActionTaken:
+		return true
+	case BlindPosted:
+		return e.Kind == Straddle
+	}
+	return false
+}
+
+// startTable records the table and writes the header of the hand, or returns
+// nil when the hand's betting structure cannot be written
+func (h *HandHistory) startTable(e HandStarted) *historyTable {
+	g := h.g
+	clock := h.Clock
+	if clock == nil {
+		clock = systemClock{}
+	}
+
+	var game string
+	small, big := g.smallBlind(), g.BigBlind
+	switch b := g.betting().(type) {
+	case NoLimit:
+		game = "Hold'em No Limit"
+	case PotLimit:
+		game = "Hold'em Pot Limit"
+	case FixedLimit:
+		game, small, big = "Hold'em Limit", b.SmallBet, b.BigBet
+	default:
+		g.log().Info("hand history not written", slog.String("betting", fmt.Sprintf("%T", b)))
+		return nil
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "PokerStars Hand #%d: %s ($%d/$%d USD) - %s\n", e.Hand, game, small, big, clock.Now().Format("2006/01/02 15:04:05 MST"))
+	fmt.Fprintf(&b, "Table '%s' %d-max Seat #%d is the button\n", g.ID, g.seatCount(), g.buttonSeat+1)
+
+	table := &historyTable{}
+	for _, p := range g.Players {
+		seat := historySeat{seat: p.seat + 1, name: p.Name, money: p.money, sittingOut: p.PlayerStatus == SittingOut}
+		if p.seat == g.buttonSeat {
+			seat.roles += " (button)"
+		}
+		if !seat.sittingOut && p.seat == g.smallBlindSeat {
+			seat.roles += " (small blind)"
+		}
+		if p.seat == g.bigBlindSeat {
+			seat.roles += " (big blind)"
+		}
+		table.seats = append(table.seats, seat)
+
+		fmt.Fprintf(&b, "Seat %d: %s ($%d in chips)", seat.seat, seat.name, seat.money)
+		if seat.sittingOut {
+			fmt.Fprint(&b, " is sitting out")
+		}
+		fmt.Fprintln(&b)
+	}
+	table.header = b.String()
+	return table
+}
+
+// historyAward is a pot, or what is left of it once an uncalled bet is returned
+type historyAward struct {
+	winners []string
+	shares  []int
+}
+
+// write renders the hand from its events, PokerStars style, with the hole
+// cards of the hero only unless it is empty
+func (t *historyTable) write(hero string) string {
+	var b strings.Builder
+	b.WriteString(t.header)
+
+	var (
+		dealt     = map[string][]Card{}
+		order     = []string{} // Players in the order they were dealt
+		holeCards bool
+		street    = PreFlop
+		bets      = map[string]int{} // Bets on the current street
+		highest   int
+		folded    = map[string]string{}
+		uncalled  = map[string]int{}
+		shown     = map[string]ShownHand{}
+		showdown  bool
+		board     []Card
+		awards    []historyAward
+	)
+
+	// closeStreet returns the part of the last bet nobody called once the betting is over
+	closed := false
+	closeStreet := func() {
+		if closed {
+			return
+		}
+		closed = true
+		top, second := "", 0
+		for name, bet := range bets {
+			if top == "" || bet > bets[top] {
+				top = name
+			}
+		}
+		for name, bet := range bets {
+			if name != top {
+				second = max(second, bet)
+			}
+		}
+		if top != "" && bets[top] > second {
+			uncalled[top] += bets[top] - second
+			fmt.Fprintf(&b, "Uncalled bet ($%d) returned to %s\n", bets[top]-second, top)
+		}
+	}
+	showHoleCards := func() {
+		if holeCards {
+			return
+		}
+		holeCards = true
+		b.WriteString("*** HOLE CARDS ***\n")
+		for _, name := range order {
+			if hero == "" || name == hero {
+				fmt.Fprintf(&b, "Dealt to %s %s\n", name, cardCodes(dealt[name]))
+			}
+		}
+	}
+
+	for _, e := range t.events {
+		switch e := e.(type) {
+		case CardsDealt:
+			dealt[e.Player] = e.Cards
+			order = append(order, e.Player)
+		case BlindPosted:
+			switch e.Kind {
+			case Ante:
+				fmt.Fprintf(&b, "%s: posts the ante $%d\n", e.Player, e.Amount)
+			case DeadSmallBlind:
+				fmt.Fprintf(&b, "%s: posts dead small blind $%d\n", e.Player, e.Amount)
+			default:
+				fmt.Fprintf(&b, "%s: posts %s $%d\n", e.Player, e.Kind, e.Amount)
+				bets[e.Player] += e.Amount
+				highest = max(highest, bets[e.Player])
+			}
+		case ActionTaken:
+			showHoleCards()
+			fmt.Fprintf(&b, "%s: %s\n", e.Player, describeAction(e, highest))
+			bets[e.Player] = e.Bet
+			highest = max(highest, e.Bet)
+			if e.Action == ActionFold {
+				folded[e.Player] = "folded on the " + street.String()
+				if street == PreFlop {
+					folded[e.Player] = "folded before Flop"
+					if e.Bet == 0 {
+						folded[e.Player] += " (didn't bet)"
+					}
+				}
+			}
+		case StreetDealt:
+			showHoleCards()
+			closeStreet()
+			closed, street, bets, highest = false, e.Street, map[string]int{}, 0
+			board = e.Board
+			fmt.Fprintf(&b, "*** %s ***", strings.ToUpper(e.Street.String()))
+			if previous := e.Board[:len(e.Board)-len(e.Cards)]; len(previous) > 0 {
+				fmt.Fprintf(&b, " %s", cardCodes(previous))
+			}
+			fmt.Fprintf(&b, " %s\n", cardCodes(e.Cards))
+		case Showdown:
+			showHoleCards()
+			closeStreet()
+			showdown = true
+			b.WriteString("*** SHOW DOWN ***\n")
+			for _, hand := range e.Hands {
+				shown[hand.Player] = hand
+				fmt.Fprintf(&b, "%s: shows %s (%s)\n", hand.Player, cardCodes(hand.Cards), describeHand(hand.Hand))
+			}
+		case PotAwarded:
+			showHoleCards()
+			closeStreet()
+			awards = append(awards, historyAward{winners: e.Winners, shares: slices.Clone(e.Shares)})
+		}
+	}
+
+	// The uncalled bets are in the last pot won by their player alone
+	for name, amount := range uncalled {
+		for i := len(awards) - 1; i >= 0; i-- {
+			if len(awards[i].winners) == 1 && awards[i].winners[0] == name {
+				awards[i].shares[0] -= amount
+				break
+			}
+		}
+	}
+	awards = slices.DeleteFunc(awards, func(a historyAward) bool {
+		return sum(a.shares) <= 0
+	})
+
+	total, won := 0, map[string]int{}
+	pots := ""
+	for i, award := range awards {
+		pot := "pot"
+		if len(awards) > 1 {
+			pot = "main pot"
+			if i > 0 {
+				pot = fmt.Sprintf("side pot-%d", i)
+			}
+			pots += fmt.Sprintf(" %s%s $%d.", strings.ToUpper(pot[:1]), pot[1:], sum(award.shares))
+		}
+		for k, name := range award.winners {
+			fmt.Fprintf(&b, "%s collected $%d from %s\n", name, award.shares[k], pot)
+			won[name] += award.shares[k]
+		}
+		total += sum(award.shares)
+	}
+	if !showdown {
+		for _, award := range awards {
+			fmt.Fprintf(&b, "%s: doesn't show hand\n", award.winners[0])
+			break
+		}
+	}
+
+	b.WriteString("*** SUMMARY ***\n")
+	fmt.Fprintf(&b, "Total pot $%d%s | Rake $0\n", total, pots)
+	if len(board) > 0 {
+		fmt.Fprintf(&b, "Board %s\n", cardCodes(board))
+	}
+	for _, seat := range t.seats {
+		if seat.sittingOut {
+			continue
+		}
+		fmt.Fprintf(&b, "Seat %d: %s%s ", seat.seat, seat.name, seat.roles)
+		hand, showed := shown[seat.name]
+		switch {
+		case folded[seat.name] != "":
+			b.WriteString(folded[seat.name])
+		case showed && won[seat.name] > 0:
+			fmt.Fprintf(&b, "showed %s and won ($%d) with %s", cardCodes(hand.Cards), won[seat.name], describeHand(hand.Hand))
+		case showed:
+			fmt.Fprintf(&b, "showed %s and lost with %s", cardCodes(hand.Cards), describeHand(hand.Hand))
+		case won[seat.name] > 0:
+			fmt.Fprintf(&b, "collected ($%d)", won[seat.name])
+		default:
+			b.WriteString("mucked")
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("\n\n")
+	return b.String()
+}
+
+// describeAction writes a player's action, PokerStars style, given the highest bet before it
+func describeAction(e ActionTaken, highest int) string {
+	switch e.Action {
+	case ActionFold:
+		return "folds"
+	case ActionCheck:
+		return "checks"
+	case ActionCall:
+		return fmt.Sprintf("calls $%d", e.Amount)
+	case ActionBet:
+		return fmt.Sprintf("bets $%d", e.Amount)
+	case ActionRaise:
+		return fmt.Sprintf("raises $%d to $%d", e.Bet-highest, e.Bet)
+	}
+	switch {
+	case e.Bet <= highest:
+		return fmt.Sprintf("calls $%d and is all-in", e.Amount)
+	case highest == 0:
+		return fmt.Sprintf("bets $%d and is all-in", e.Amount)
+	default:
+		return fmt.Sprintf("raises $%d to $%d and is all-in", e.Bet-highest, e.Bet)
+	}
+}
+
+// cardValueCodes and cardSuitCodes are the characters of cards in hand histories, e.g. Ah or Td
+var (
+	cardValueCodes = [...]string{Ace: "A", Two: "2", Three: "3", Four: "4", Five: "5", Six: "6", Seven: "7",
+		Eight: "8", Nine: "9", Ten: "T", Jack: "J", Queen: "Q", King: "K"}
+	cardSuitCodes = [...]string{Spades: "s", Hearts: "h", Diamonds: "d", Clubs: "c"}
+)
+
+// cardCodes writes the cards the way hand histories do, e.g. [Ah Kd]
+func cardCodes(cards []Card) string {
+	codes := make([]string, len(cards))
+	for i, c := range cards {
+		codes[i] = cardValueCodes[c.Value] + cardSuitCodes[c.Suit]
+	}
+	return "[" + strings.Join(codes, " ") + "]"
+}
+
+// valueNames are the names of card values in hand descriptions, aces high or low
+var valueNames = [...]string{1: "Ace", 2: "Deuce", 3: "Three", 4: "Four", 5: "Five", 6: "Six", 7: "Seven",
+	8: "Eight", 9: "Nine", 10: "Ten", 11: "Jack", 12: "Queen", 13: "King", 14: "Ace"}
+
+// valuePlural names several cards of a value, e.g. Sixes
+func valuePlural(v int) string {
+	if v == 6 {
+		return "Sixes"
+	}
+	return valueNames[v] + "s"
+}
+
+// describeHand names a ranked hand the way PokerStars does, e.g. "two pair, Kings and Jacks"
+func describeHand(h Hand) string {
+	v := h.Values
+	switch h.Rank {
+	case OnePair:
+		return "a pair of " + valuePlural(v[0])
+	case TwoPair:
+		return fmt.Sprintf("two pair, %s and %s", valuePlural(v[0]), valuePlural(v[1]))
+	case ThreeOfAKind:
+		return "three of a kind, " + valuePlural(v[0])
+	case Straight:
+		return fmt.Sprintf("a straight, %s to %s", valueNames[v[0]-4], valueNames[v[0]])
+	case Flush:
+		return fmt.Sprintf("a flush, %s high", valueNames[v[0]])
+	case FullHouse:
+		return fmt.Sprintf("a full house, %s full of %s", valuePlural(v[0]), valuePlural(v[1]))
+	case FourOfAKind:
+		return "four of a kind, " + valuePlural(v[0])
+	case StraightFlush:
+		if v[0] == 14 {
+			return "a Royal Flush"
+		}
+		return fmt.Sprintf("a straight flush, %s to %s", valueNames[v[0]-4], valueNames[v[0]])
+	default:
+		return "high card " + valueNames[v[0]]
+	}
+}
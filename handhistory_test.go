@@ -0,0 +1,215 @@
+package poker
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newHistoryGame creates a game writing its hand history to out, shuffled
+// with the same seed every hand and on a clock that stands still
+func newHistoryGame(out *bytes.Buffer, names ...string) (*Game, *HandHistory) {
+	g := newSeatedGame(1000, 50, names...)
+	g.ID = "Table 1"
+	g.SeedSource = func() int64 { return 1 }
+	history := NewHandHistory(out, g)
+	history.Clock = &fakeClock{time.Date(2024, 1, 1, 18, 0, 0, 0, time.UTC)}
+	g.Subscribe(history)
+	return g, history
+}
+
+func TestHandHistory(t *testing.T) {
+	var out bytes.Buffer
+	g, _ := newHistoryGame(&out, "Alice", "Bob", "Charlie")
+	g.StartGame()
+	g.PreFlop()
+	g.getPlayer("Alice", -1).Raise(100, g)
+	g.getPlayer("Bob", -1).Fold(g)
+	g.getPlayer("Charlie", -1).Call(g)
+	g.getPlayer("Charlie", -1).Check(g)
+	g.getPlayer("Alice", -1).Raise(200, g)
+	g.getPlayer("Charlie", -1).Call(g)
+	g.getPlayer("Charlie", -1).Raise(400, g)
+	g.getPlayer("Alice", -1).AllIn(g)
+	g.getPlayer("Charlie", -1).Call(g)
+
+	expected := `PokerStars Hand #1: Hold'em No Limit ($25/$50 USD) - 2024/01/01 18:00:00 UTC
+Table 'Table 1' 10-max Seat #1 is the button
+Seat 1: Alice ($1000 in chips)
+Seat 2: Bob ($1000 in chips)
+Seat 3: Charlie ($1000 in chips)
+Bob: posts small blind $25
+Charlie: posts big blind $50
+*** HOLE CARDS ***
+Dealt to Bob [Kd Ad]
+Dealt to Charlie [5c Kc]
+Dealt to Alice [Ah Th]
+Alice: raises $50 to $100
+Bob: folds
+Charlie: calls $50
+*** FLOP *** [8c Qd 6h]
+Charlie: checks
+Alice: bets $200
+Charlie: calls $200
+*** TURN *** [8c Qd 6h] [9d]
+Charlie: bets $400
+Alice: raises $300 to $700 and is all-in
+Charlie: calls $300 and is all-in
+*** RIVER *** [8c Qd 6h 9d] [Qh]
+*** SHOW DOWN ***
+Alice: shows [Ah Th] (a pair of Queens)
+Charlie: shows [5c Kc] (a pair of Queens)
+Alice collected $2025 from pot
+*** SUMMARY ***
+Total pot $2025 | Rake $0
+Board [8c Qd 6h 9d Qh]
+Seat 1: Alice (button) showed [Ah Th] and won ($2025) with a pair of Queens
+Seat 2: Bob (small blind) folded before Flop
+Seat 3: Charlie (big blind) showed [5c Kc] and lost with a pair of Queens
+
+
+`
+	if out.String() != expected {
+		t.Errorf("Expected the hand history\n%s\ngot\n%s", expected, out.String())
+	}
+}
+
+func TestHandHistoryUncalledBet(t *testing.T) {
+	var out bytes.Buffer
+	g, history := newHistoryGame(&out, "Alice", "Bob")
+	history.Hero = "Bob"
+	g.StartGame()
+	g.PreFlop()
+	g.getPlayer("Alice", -1).Raise(150, g)
+	g.getPlayer("Bob", -1).Fold(g)
+
+	expected := `PokerStars Hand #1: Hold'em No Limit ($25/$50 USD) - 2024/01/01 18:00:00 UTC
+Table 'Table 1' 10-max Seat #1 is the button
+Seat 1: Alice ($1000 in chips)
+Seat 2: Bob ($1000 in chips)
+Alice: posts small blind $25
+Bob: posts big blind $50
+*** HOLE CARDS ***
+Dealt to Bob [Kd Ad]
+Alice: raises $125 to $175
+Bob: folds
+Uncalled bet ($125) returned to Alice
+Alice collected $100 from pot
+Alice: doesn't show hand
+*** SUMMARY ***
+Total pot $100 | Rake $0
+Seat 1: Alice (button) (small blind) collected ($100)
+Seat 2: Bob (big blind) folded before Flop
+
+
+`
+	if out.String() != expected {
+		t.Errorf("Expected the hand history\n%s\ngot\n%s", expected, out.String())
+	}
+}
+
+func TestHandHistorySidePot(t *testing.T) {
+	var out bytes.Buffer
+	g, _ := newHistoryGame(&out, "Alice", "Bob", "Charlie")
+	g.getPlayer("Charlie", -1).money = 200
+	g.StartGame()
+	g.PreFlop()
+	g.getPlayer("Alice", -1).Raise(300, g)
+	g.getPlayer("Bob", -1).Call(g)
+	g.getPlayer("Charlie", -1).Call(g)
+	for g.GameStatus != DetermineWinner {
+		g.CurrentPlayer().Check(g)
+	}
+
+	for _, line := range []string{
+		"Charlie: calls $150 and is all-in\n",
+		"Bob collected $600 from main pot\nBob collected $200 from side pot-1\n",
+		"Total pot $800 Main pot $600. Side pot-1 $200. | Rake $0\n",
+		"Seat 2: Bob (small blind) showed [Kd Ad] and won ($800) with a pair of Queens\n",
+	} {
+		if !strings.Contains(out.String(), line) {
+			t.Errorf("Expected the hand history to contain %q, got\n%s", line, out.String())
+		}
+	}
+}
+
+func TestHandHistoryUndo(t *testing.T) {
+	var out bytes.Buffer
+	g, _ := newHistoryGame(&out, "Alice", "Bob", "Charlie")
+	g.StartGame()
+	g.PreFlop()
+	g.getPlayer("Alice", -1).Raise(100, g)
+	g.getPlayer("Bob", -1).Call(g)
+	g.Undo()
+	g.getPlayer("Bob", -1).Fold(g)
+	g.getPlayer("Charlie", -1).Fold(g)
+
+	if strings.Contains(out.String(), "Bob: calls") {
+		t.Errorf("Expected the call taken back to be left out, got\n%s", out.String())
+	}
+	if !strings.Contains(out.String(), "Alice: raises $50 to $100\nBob: folds\nCharlie: folds\nUncalled bet ($50) returned to Alice\nAlice collected $125 from pot\n") {
+		t.Errorf("Expected Bob to fold after the raise, got\n%s", out.String())
+	}
+
+	// Taking back the fold that ended the hand writes it again once it is over
+	out.Reset()
+	if err := g.Undo(); err != nil {
+		t.Fatalf("Expected Charlie's fold to be taken back, got %v", err)
+	}
+	if out.Len() != 0 {
+		t.Errorf("Expected nothing to be written until the hand is over again, got\n%s", out.String())
+	}
+	g.getPlayer("Charlie", -1).Call(g)
+	for g.GameStatus != DetermineWinner {
+		g.CurrentPlayer().Check(g)
+	}
+	if strings.Count(out.String(), "PokerStars Hand #1:") != 1 || !strings.Contains(out.String(), "Bob: folds\nCharlie: calls $50\n*** FLOP ***") {
+		t.Errorf("Expected the hand to be written again with Charlie's call, got\n%s", out.String())
+	}
+}
+
+func TestHandHistorySpreadLimit(t *testing.T) {
+	var out bytes.Buffer
+	g, _ := newHistoryGame(&out, "Alice", "Bob")
+	g.Betting = SpreadLimit{Min: 50, Max: 200}
+	g.StartGame()
+	g.PreFlop()
+	g.getPlayer("Alice", -1).Raise(150, g)
+	g.getPlayer("Bob", -1).Fold(g)
+
+	// PokerStars has no spread limit games, the hand is left out rather than mislabelled
+	if out.Len() != 0 {
+		t.Errorf("Expected no hand history for a spread limit game, got\n%s", out.String())
+	}
+}
+
+func TestDescribeHand(t *testing.T) {
+	tests := []struct {
+		cards    string
+		expected string
+	}{
+		{"Ah Kd 9c 7s 2h", "high card Ace"},
+		{"6h 6d 9c 7s 2h", "a pair of Sixes"},
+		{"Kh Kd Jc Js 2h", "two pair, Kings and Jacks"},
+		{"2h 2d 2c 7s Ah", "three of a kind, Deuces"},
+		{"Ah 2d 3c 4s 5h", "a straight, Ace to Five"},
+		{"Th Jd Qc Ks Ah", "a straight, Ten to Ace"},
+		{"Ah 9h 7h 4h 2h", "a flush, Ace high"},
+		{"Qh Qd Qc 9s 9h", "a full house, Queens full of Nines"},
+		{"8h 8d 8c 8s Ah", "four of a kind, Eights"},
+		{"5s 6s 7s 8s 9s", "a straight flush, Five to Nine"},
+		{"Ts Js Qs Ks As", "a Royal Flush"},
+	}
+	for _, tt := range tests {
+		cards := []Card{}
+		for _, code := range strings.Fields(tt.cards) {
+			value := strings.Index("A23456789TJQK", code[:1]) + 1
+			suit := strings.Index("shdc", code[1:])
+			cards = append(cards, Card{Suit: Suit(suit), Value: Value(value)})
+		}
+		if got := describeHand(BestHand(cards)); got != tt.expected {
+			t.Errorf("Expected %s to be %q, got %q", tt.cards, tt.expected, got)
+		}
+	}
+}
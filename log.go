@@ -0,0 +1,101 @@
+package poker
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"sync/atomic"
+)
+
+// defaultLogger is used by games that have no logger of their own
+var defaultLogger atomic.Pointer[slog.Logger]
+
+func init() {
+	defaultLogger.Store(slog.New(slog.DiscardHandler))
+}
+
+// SetDefaultLogger sets the logger used by every game without a logger of its own,
+// including the messages logged while a game is created. Passing nil silences them.
+func SetDefaultLogger(l *slog.Logger) {
+	if l == nil {
+		l = slog.New(slog.DiscardHandler)
+	}
+	defaultLogger.Store(l)
+}
+
+// SetLogger sets the logger the game writes its messages to.
+// Passing nil reverts to the default logger, which is silent unless set with SetDefaultLogger.
+func (g *Game) SetLogger(l *slog.Logger) {
+	g.logger = l
+}
+
+// log returns the game's logger with the game ID and hand number attached
+func (g *Game) log() *slog.Logger {
+	l := g.logger
+	if l == nil {
+		l = defaultLogger.Load()
+	}
+	return l.With(slog.String("game", g.ID), slog.Int("hand", g.HandNumber))
+}
+
+// newGameID returns a random identifier to correlate the messages of a game
+func newGameID() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// cardsAttr formats cards as a structured logging attribute
+func cardsAttr(key string, cards []Card) slog.Attr {
+	names := make([]string, len(cards))
+	for i, c := range cards {
+		names[i] = c.String()
+	}
+	return slog.Any(key, names)
+}
+
+// logEvent writes an event to the game's logger
+func (g *Game) logEvent(e Event) {
+	l := g.log()
+	switch e := e.(type) {
+	case HandStarted:
+		l.Info("hand started", slog.String("dealer", e.Dealer))
+	case LevelStarted:
+		l.Info("level started", slog.Int("level", e.Level), slog.Int("big_blind", e.BigBlind), slog.Int("ante", e.Ante))
+	case ChipsColoredUp:
+		l.Info("chips colored up", slog.String("player", e.Player), slog.Int("before", e.Before), slog.Int("after", e.After))
+	case PlayerJoined:
+		l.Info("player joined", slog.String("player", e.Player), slog.Int("money", e.Money))
+	case BlindPosted:
+		l.Info("blind posted", slog.String("player", e.Player), slog.String("blind", e.Kind.String()), slog.Int("amount", e.Amount))
+	case CardsDealt:
+		l.Debug("cards dealt", slog.String("player", e.Player), cardsAttr("cards", e.Cards))
+	case ActionTaken:
+		l.Info("action taken", slog.String("player", e.Player), slog.String("action", e.Action.String()),
+			slog.Int("amount", e.Amount), slog.Int("bet", e.Bet))
+	case StreetDealt:
+		l.Info("community cards dealt", slog.String("street", e.Street.String()), cardsAttr("cards", e.Cards), cardsAttr("board", e.Board))
+	case PotCreated:
+		l.Info("pot created", slog.Int("pot", e.Pot), slog.Int("amount", e.Amount), slog.Any("eligible", e.Eligible))
+	case Showdown:
+		for _, h := range e.Hands {
+			l.Info("hand shown", slog.String("player", h.Player), cardsAttr("cards", h.Cards), slog.String("rank", h.Hand.Rank.String()))
+		}
+		l.Info("winners determined", slog.Any("winners", e.Winners))
+	case PotAwarded:
+		l.Info("pot awarded", slog.Int("pot", e.Pot), slog.Int("amount", e.Amount), slog.Any("winners", e.Winners))
+	case PlayerLeft:
+		l.Info("player left", slog.String("player", e.Player), slog.Int("money", e.Money))
+	case PlayerSatOut:
+		l.Info("player sat out", slog.String("player", e.Player))
+	case PlayerSatIn:
+		l.Info("player sat in", slog.String("player", e.Player))
+	case ChipsAdded:
+		l.Info("chips added", slog.String("player", e.Player), slog.Int("amount", e.Amount), slog.Int("money", e.Money))
+	case PlayerEliminated:
+		l.Info("player eliminated", slog.String("player", e.Player))
+	case ActionUndone:
+		l.Info("action undone", slog.String("player", e.Player), slog.String("action", e.Action.String()),
+			slog.Int("amount", e.Amount), slog.Bool("straddle", e.Straddle))
+	}
+}
@@ -0,0 +1,99 @@
+package poker
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+)
+
+// logRecords decodes the JSON log lines written to buf
+func logRecords(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var records []map[string]any
+	dec := json.NewDecoder(buf)
+	for dec.More() {
+		record := map[string]any{}
+		if err := dec.Decode(&record); err != nil {
+			t.Fatalf("Failed to decode log record: %v", err)
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestGameLogger(t *testing.T) {
+	var buf bytes.Buffer
+	game := NewGame(1000, 50)
+	game.SetLogger(slog.New(slog.NewJSONHandler(&buf, nil)))
+	game.Initialise()
+	game.AddPlayer("Alice")
+	game.AddPlayer("Bob")
+	for i := range game.Players {
+		game.Players[i].IsReady = true
+	}
+	game.StartGame()
+	game.PreFlop()
+	game.getPlayer("Alice", 0).Raise(75, game)
+
+	records := logRecords(t, &buf)
+	if len(records) == 0 {
+		t.Fatal("Expected log records")
+	}
+	for _, record := range records {
+		if record["game"] != game.ID {
+			t.Errorf("Expected game ID %s on every record, got %v", game.ID, record)
+		}
+	}
+
+	last := records[len(records)-1]
+	if last["msg"] != "action taken" || last["player"] != "Alice" || last["action"] != "Raise" ||
+		last["amount"] != float64(75) || last["bet"] != float64(100) || last["hand"] != float64(1) {
+		t.Errorf("Unexpected action record %v", last)
+	}
+}
+
+func TestDefaultLogger(t *testing.T) {
+	var buf bytes.Buffer
+	SetDefaultLogger(slog.New(slog.NewJSONHandler(&buf, nil)))
+	defer SetDefaultLogger(nil)
+
+	game := NewGame(1000, 50)
+	records := logRecords(t, &buf)
+	if len(records) != 1 || records[0]["msg"] != "starting a new game" || records[0]["big_blind"] != float64(50) {
+		t.Errorf("Unexpected records %v", records)
+	}
+
+	// A game's own logger takes precedence over the default
+	game.SetLogger(slog.New(slog.DiscardHandler))
+	game.AddPlayer("Alice")
+	if buf.Len() != 0 {
+		t.Errorf("Expected nothing to be logged to the default logger, got %s", buf.String())
+	}
+}
+
+func TestUndoLogging(t *testing.T) {
+	var buf bytes.Buffer
+	SetDefaultLogger(slog.New(slog.NewJSONHandler(&buf, nil)))
+	defer SetDefaultLogger(nil)
+
+	game := newStartedGame(1000, 50, "Alice", "Bob", "Charlie")
+	game.getPlayer("Alice", -1).Raise(150, game)
+	game.getPlayer("Bob", -1).Fold(game)
+	buf.Reset()
+
+	// The hand replayed to take the fold back is not logged again
+	if err := game.Undo(); err != nil {
+		t.Fatalf("Expected the fold to be taken back, got %v", err)
+	}
+	records := logRecords(t, &buf)
+	if len(records) != 1 || records[0]["msg"] != "action undone" || records[0]["game"] != game.ID {
+		t.Errorf("Expected only the undo to be logged, got %v", records)
+	}
+}
+
+func TestGameIDs(t *testing.T) {
+	if NewGame(1000, 50).ID == NewGame(1000, 50).ID {
+		t.Error("Expected games to have distinct IDs")
+	}
+}
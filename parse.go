@@ -0,0 +1,700 @@
+package poker
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParsedHand is a hand read from a text hand history in the PokerStars format,
+// see ParseHandHistory. Amounts are whole chips, or cents when any amount of
+// the hand has cents, e.g. $0.25/$0.50 or a $12.30 stack, see Scale.
+type ParsedHand struct {
+	Number     int
+	Table      string
+	Time       time.Time        // As written in the history, read as UTC whatever its time zone
+	Betting    BettingStructure // NoLimit, PotLimit or FixedLimit
+	SmallBlind int
+	BigBlind   int
+	Ante       int
+	AnteRule   AnteRule      // Worked out from who posted the ante
+	Scale      int           // What the amounts of the history were multiplied by, 100 when any of them has cents
+	MaxSeats   int           // Seats at the table
+	ButtonSeat int           // Numbered from 0, like the seats of a Game
+	Players    []*Player     // In seat order, with the money they started the hand with and their hole cards when known
+	Posts      []BlindPosted // Blinds and antes in the order they were posted
+	Actions    []Action      // Straddles, and bets from the preflop betting on
+	Board      []Card
+	Pots       []Pot        // The pots the hand was played for, eligible players by ID
+	Awards     []PotAwarded // What the players collected from each pot, once the rake was taken
+	Rake       int
+}
+
+// Player returns the player of the hand with the given name, or nil if none
+func (h ParsedHand) Player(name string) *Player {
+	for _, p := range h.Players {
+		if p.Name == name {
+			return p
+		}
+	}
+	return nil
+}
+
+// amountPattern matches an amount of a hand history, e.g. $1,250.50 or 1500
+const amountPattern = `[$€£]?[\d,]+(?:\.\d+)?`
+
+var (
+	headerLine    = regexp.MustCompile(`^PokerStars (?:Zoom )?(?:Hand|Game) #(\d+):\s*(.*)$`)
+	stakesPart    = regexp.MustCompile(`\((` + amountPattern + `)/(` + amountPattern + `)(?: [A-Z]{3})?\)`)
+	timePart      = regexp.MustCompile(`\d{4}/\d{1,2}/\d{1,2} \d{1,2}:\d{2}:\d{2}`)
+	tableLine     = regexp.MustCompile(`^Table '(.*)' (\d+)-max.*Seat #(\d+) is the button`)
+	seatLine      = regexp.MustCompile(`^Seat (\d+): (.+?) \((` + amountPattern + `) in chips[^)]*\)(.*)$`)
+	postPart      = regexp.MustCompile(`^posts (small blind|big blind|small & big blinds|the ante|straddle|dead small blind) (` + amountPattern + `)`)
+	dealtLine     = regexp.MustCompile(`^Dealt to (.+?) \[([^\]]+)\]`)
+	callPart      = regexp.MustCompile(`^(calls|bets) (` + amountPattern + `)( and is all-in)?`)
+	raisePart     = regexp.MustCompile(`^raises (` + amountPattern + `) to (` + amountPattern + `)( and is all-in)?`)
+	cardsPart     = regexp.MustCompile(`\[([^\]]*)\]`)
+	streetLine    = regexp.MustCompile(`^\*\*\* (FLOP|TURN|RIVER) \*\*\*(.*)$`)
+	uncalledLine  = regexp.MustCompile(`^Uncalled bet \((` + amountPattern + `)\) returned to (.+)$`)
+	collectedPart = regexp.MustCompile(`^collected (` + amountPattern + `) from (?:the )?(pot|main pot|side pot(?:-(\d+))?)`)
+	totalLine     = regexp.MustCompile(`^Total pot .*\| Rake (` + amountPattern + `)`)
+	summarySeat   = regexp.MustCompile(`^Seat \d+: (.*)$`)
+	centsAmount   = regexp.MustCompile(`[$€£][\d,]+\.\d`)
+)
+
+// ParseHandHistories reads every hand of a text file of PokerStars-format hand
+// histories, e.g. as written by HandHistory. Each hand starts with its
+// "PokerStars Hand #" line, anything before the first hand is skipped.
+func ParseHandHistories(r io.Reader) ([]ParsedHand, error) {
+	hands := []ParsedHand{}
+	var text strings.Builder
+	flush := func() error {
+		if text.Len() == 0 {
+			return nil
+		}
+		hand, err := ParseHandHistory(text.String())
+		if err != nil {
+			return fmt.Errorf("hand %d of the file: %w", len(hands)+1, err)
+		}
+		hands = append(hands, hand)
+		text.Reset()
+		return nil
+	}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimPrefix(scanner.Text(), "\uFEFF")
+		if strings.HasPrefix(line, "PokerStars ") {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		}
+		if text.Len() > 0 || strings.HasPrefix(line, "PokerStars ") {
+			text.WriteString(line + "\n")
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	return hands, nil
+}
+
+// handParser follows a hand history line by line
+type handParser struct {
+	hand        ParsedHand
+	line        int
+	summary     bool
+	bets        map[string]int // Bets on the current street
+	contributed map[string]int // Chips each player put in the pots
+	folded      map[string]bool
+	deadMoney   int // Dead small blinds, which belong to the main pot
+}
+
+// ParseHandHistory reads a single hand from its PokerStars-format text hand
+// history. Hold'em no-limit, pot-limit and limit cash game and tournament
+// hands can be read, lines the hand does not need, such as the chat, are
+// skipped. Returns an ErrInvalidHandHistory error for a hand it cannot read.
+func ParseHandHistory(text string) (ParsedHand, error) {
+	p := &handParser{
+		hand:        ParsedHand{ButtonSeat: -1, Players: []*Player{}, Posts: []BlindPosted{}, Actions: []Action{}, Board: []Card{}},
+		bets:        map[string]int{},
+		contributed: map[string]int{},
+		folded:      map[string]bool{},
+	}
+	// Amounts are read in cents as soon as one has cents, even a stack or the
+	// rake of a hand with whole stakes
+	p.hand.Scale = 1
+	if centsAmount.MatchString(text) {
+		p.hand.Scale = 100
+	}
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		p.line++
+		if err := p.parseLine(strings.TrimSpace(line)); err != nil {
+			return ParsedHand{}, fmt.Errorf("%w: line %d: %w", ErrInvalidHandHistory, p.line, err)
+		}
+	}
+	if err := p.finish(); err != nil {
+		return ParsedHand{}, fmt.Errorf("%w: hand #%d: %w", ErrInvalidHandHistory, p.hand.Number, err)
+	}
+	return p.hand, nil
+}
+
+// parseLine reads one line of the history into the hand
+func (p *handParser) parseLine(line string) error {
+	h := &p.hand
+	if line == "" {
+		return nil
+	}
+	if p.line == 1 {
+		return p.parseHeader(line)
+	}
+
+	switch {
+	case line == "*** SUMMARY ***":
+		p.summary = true
+	case p.summary:
+		return p.parseSummary(line)
+	case tableLine.MatchString(line):
+		m := tableLine.FindStringSubmatch(line)
+		h.Table = m[1]
+		h.MaxSeats, _ = strconv.Atoi(m[2])
+		button, _ := strconv.Atoi(m[3])
+		h.ButtonSeat = button - 1
+	case seatLine.MatchString(line) && len(p.bets) == 0:
+		m := seatLine.FindStringSubmatch(line)
+		seat, _ := strconv.Atoi(m[1])
+		money, err := p.amount(m[3])
+		if err != nil {
+			return err
+		}
+		player := NewPlayer(m[2], money)
+		player.ID = len(h.Players) + 1
+		player.seat = seat - 1
+		player.IsReady = true
+		if strings.Contains(m[4], "sitting out") {
+			player.sittingOut = true
+			player.PlayerStatus = SittingOut
+		}
+		h.Players = append(h.Players, player)
+	case dealtLine.MatchString(line):
+		m := dealtLine.FindStringSubmatch(line)
+		return p.setHoleCards(m[1], m[2])
+	case streetLine.MatchString(line):
+		m := streetLine.FindStringSubmatch(line)
+		groups := cardsPart.FindAllStringSubmatch(m[2], -1)
+		if len(groups) == 0 || strings.Count(m[2], "[") != len(groups) {
+			return fmt.Errorf("no complete board in %q", line)
+		}
+		board := []Card{}
+		for _, group := range groups {
+			cards, err := parseCards(group[1])
+			if err != nil {
+				return err
+			}
+			board = append(board, cards...)
+		}
+		h.Board = board
+		p.bets = map[string]int{"": 0} // Keeps the seats from being read again
+	case uncalledLine.MatchString(line):
+		m := uncalledLine.FindStringSubmatch(line)
+		amount, err := p.amount(m[1])
+		if err != nil {
+			return err
+		}
+		p.contributed[m[2]] -= amount
+	default:
+		if player, rest := p.actor(line); player != nil {
+			return p.parseAction(player, rest)
+		}
+	}
+	return nil
+}
+
+// parseHeader reads the hand number, game, stakes and time from the first line
+func (p *handParser) parseHeader(line string) error {
+	h := &p.hand
+	m := headerLine.FindStringSubmatch(line)
+	if m == nil {
+		return fmt.Errorf("expected a PokerStars hand to start, got %q", line)
+	}
+	h.Number, _ = strconv.Atoi(m[1])
+
+	stakes := stakesPart.FindStringSubmatch(m[2])
+	if stakes == nil {
+		return fmt.Errorf("no stakes in %q", line)
+	}
+	small, err := p.amount(stakes[1])
+	if err != nil {
+		return err
+	}
+	big, err := p.amount(stakes[2])
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case strings.Contains(m[2], "Hold'em No Limit"):
+		h.Betting, h.SmallBlind, h.BigBlind = NoLimit{}, small, big
+	case strings.Contains(m[2], "Hold'em Pot Limit"):
+		h.Betting, h.SmallBlind, h.BigBlind = PotLimit{}, small, big
+	case strings.Contains(m[2], "Hold'em Limit"):
+		// The stakes of a limit game are its bets, the blinds are usually half the
+		// small bet and the small bet, unless a full post says otherwise
+		h.Betting = FixedLimit{SmallBet: small, BigBet: big, Cap: 4}
+		h.SmallBlind, h.BigBlind = small/2, small
+	default:
+		return fmt.Errorf("only hold'em hands can be read, got %q", line)
+	}
+
+	if at := timePart.FindString(m[2]); at != "" {
+		h.Time, err = time.Parse("2006/1/2 15:04:05", at)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// actor returns the seated player whose line it is, e.g. "Alice: folds", and the rest of the line
+func (p *handParser) actor(line string) (*Player, string) {
+	var actor *Player
+	for _, player := range p.hand.Players {
+		if strings.HasPrefix(line, player.Name+": ") || strings.HasPrefix(line, player.Name+" collected ") {
+			if actor == nil || len(player.Name) > len(actor.Name) {
+				actor = player
+			}
+		}
+	}
+	if actor == nil {
+		return nil, ""
+	}
+	return actor, strings.TrimPrefix(strings.TrimPrefix(line, actor.Name), ": ")
+}
+
+// parseAction reads a post, action, shown hand or collected pot of a player
+func (p *handParser) parseAction(player *Player, rest string) error {
+	h := &p.hand
+	name := player.Name
+	switch {
+	case postPart.MatchString(rest):
+		m := postPart.FindStringSubmatch(rest)
+		amount, err := p.amount(m[2])
+		if err != nil {
+			return err
+		}
+		return p.post(player, m[1], amount)
+	case strings.HasPrefix(rest, "folds"):
+		p.folded[name] = true
+		h.Actions = append(h.Actions, Action{Player: name, Type: ActionFold})
+	case strings.HasPrefix(rest, "checks"):
+		h.Actions = append(h.Actions, Action{Player: name, Type: ActionCheck})
+	case callPart.MatchString(rest):
+		m := callPart.FindStringSubmatch(rest)
+		amount, err := p.amount(m[2])
+		if err != nil {
+			return err
+		}
+		action := Action{Player: name, Type: ActionCall, Amount: amount}
+		if m[1] == "bets" {
+			action.Type = ActionBet
+		}
+		if m[3] != "" {
+			action.Type = ActionAllIn
+		}
+		p.bet(name, amount)
+		h.Actions = append(h.Actions, action)
+	case raisePart.MatchString(rest):
+		m := raisePart.FindStringSubmatch(rest)
+		to, err := p.amount(m[2])
+		if err != nil {
+			return err
+		}
+		action := Action{Player: name, Type: ActionRaise, Amount: to - p.bets[name]}
+		if m[3] != "" {
+			action.Type = ActionAllIn
+		}
+		p.bet(name, action.Amount)
+		h.Actions = append(h.Actions, action)
+	case strings.HasPrefix(rest, "shows ["):
+		codes, err := cardsIn(rest)
+		if err != nil {
+			return err
+		}
+		return p.setHoleCards(name, codes)
+	case collectedPart.MatchString(strings.TrimPrefix(rest, " ")):
+		m := collectedPart.FindStringSubmatch(strings.TrimPrefix(rest, " "))
+		amount, err := p.amount(m[1])
+		if err != nil {
+			return err
+		}
+		pot := 0
+		if strings.HasPrefix(m[2], "side pot") {
+			pot = 1
+			if m[3] != "" {
+				pot, _ = strconv.Atoi(m[3])
+			}
+		}
+		p.collect(name, pot, amount)
+	}
+	return nil
+}
+
+// post records a blind or ante
+func (p *handParser) post(player *Player, kind string, amount int) error {
+	h := &p.hand
+	name := player.Name
+	posted := func(kind BlindKind, amount int) {
+		h.Posts = append(h.Posts, BlindPosted{Player: name, Kind: kind, Amount: amount})
+	}
+	// Only a blind posted in full tells its size, not one the player went all in for
+	left := player.money
+	for _, b := range h.Posts {
+		if b.Player == name {
+			left -= b.Amount
+		}
+	}
+	_, limit := h.Betting.(FixedLimit)
+	sizes := limit && amount < left
+	switch kind {
+	case "the ante":
+		posted(Ante, amount)
+		h.Ante = max(h.Ante, amount)
+	case "small blind":
+		if slices.ContainsFunc(h.Posts, func(b BlindPosted) bool { return b.Kind == SmallBlind }) {
+			player.missedSmallBlind = true
+			posted(DeadSmallBlind, amount)
+			p.deadMoney += amount
+			break
+		}
+		posted(SmallBlind, amount)
+		if sizes {
+			h.SmallBlind = amount
+		}
+		p.bet(name, amount)
+	case "big blind":
+		if slices.ContainsFunc(h.Posts, func(b BlindPosted) bool { return b.Kind == BigBlind }) {
+			player.missedBigBlind = true
+		} else if sizes {
+			h.BigBlind = amount
+		}
+		posted(BigBlind, amount)
+		p.bet(name, amount)
+	case "small & big blinds":
+		if h.BigBlind == 0 || amount <= h.BigBlind {
+			return fmt.Errorf("player %s posts $%d of small and big blinds, more than the big blind of $%d is expected", name, amount, h.BigBlind)
+		}
+		player.missedSmallBlind, player.missedBigBlind = true, true
+		posted(BigBlind, h.BigBlind)
+		posted(DeadSmallBlind, amount-h.BigBlind)
+		p.bet(name, h.BigBlind)
+		p.deadMoney += amount - h.BigBlind
+	case "dead small blind":
+		player.missedSmallBlind = true
+		posted(DeadSmallBlind, amount)
+		p.deadMoney += amount
+	case "straddle":
+		posted(Straddle, amount)
+		h.Actions = append(h.Actions, Action{Player: name, Straddle: true})
+		p.bet(name, amount)
+	}
+	return nil
+}
+
+// bet adds chips to the player's bet on the current street
+func (p *handParser) bet(name string, amount int) {
+	p.bets[name] += amount
+	p.contributed[name] += amount
+}
+
+// collect records that the player collected the amount from a pot
+func (p *handParser) collect(name string, pot, amount int) {
+	h := &p.hand
+	for i := range h.Awards {
+		if h.Awards[i].Pot == pot {
+			h.Awards[i].Amount += amount
+			h.Awards[i].Winners = append(h.Awards[i].Winners, name)
+			h.Awards[i].Shares = append(h.Awards[i].Shares, amount)
+			return
+		}
+	}
+	h.Awards = append(h.Awards, PotAwarded{Pot: pot, Amount: amount, Winners: []string{name}, Shares: []int{amount}})
+}
+
+// parseSummary reads the rake, the board and the cards shown or mucked
+func (p *handParser) parseSummary(line string) error {
+	h := &p.hand
+	switch {
+	case totalLine.MatchString(line):
+		rake, err := p.amount(totalLine.FindStringSubmatch(line)[1])
+		if err != nil {
+			return err
+		}
+		h.Rake = rake
+	case strings.HasPrefix(line, "Board ["):
+		codes, err := cardsIn(line)
+		if err != nil {
+			return err
+		}
+		board, err := parseCards(codes)
+		if err != nil {
+			return err
+		}
+		h.Board = board
+	case summarySeat.MatchString(line):
+		rest := summarySeat.FindStringSubmatch(line)[1]
+		player, _ := p.actor(rest + ": ")
+		for _, candidate := range h.Players {
+			if strings.HasPrefix(rest, candidate.Name+" ") && (player == nil || len(candidate.Name) > len(player.Name)) {
+				player = candidate
+			}
+		}
+		if player == nil {
+			return nil
+		}
+		for _, verb := range []string{" showed [", " mucked ["} {
+			if i := strings.Index(rest, verb); i >= 0 {
+				codes, err := cardsIn(rest[i:])
+				if err != nil {
+					return err
+				}
+				return p.setHoleCards(player.Name, codes)
+			}
+		}
+	}
+	return nil
+}
+
+// setHoleCards gives the player the two hole cards written as e.g. "Ah Kd"
+func (p *handParser) setHoleCards(name, codes string) error {
+	player := p.hand.Player(name)
+	if player == nil {
+		return fmt.Errorf("cards dealt to %s, who is not seated", name)
+	}
+	cards, err := parseCards(codes)
+	if err != nil {
+		return err
+	}
+	if len(cards) != 2 {
+		return fmt.Errorf("player %s has %d hole cards, expected 2", name, len(cards))
+	}
+	player.CardStack = CardStack{cards}
+	return nil
+}
+
+// finish checks the hand is complete and works out its pots
+func (p *handParser) finish() error {
+	h := &p.hand
+	if len(h.Players) < 2 {
+		return fmt.Errorf("%d players are seated, at least 2 are required", len(h.Players))
+	}
+	if h.ButtonSeat < 0 {
+		return fmt.Errorf("the button is missing")
+	}
+	if !slices.ContainsFunc(h.Posts, func(b BlindPosted) bool { return b.Kind == BigBlind }) {
+		return fmt.Errorf("nobody posts the big blind")
+	}
+
+	// A single ante is posted for the whole table, by the big blind or the button
+	antes := slices.DeleteFunc(slices.Clone(h.Posts), func(b BlindPosted) bool { return b.Kind != Ante })
+	if len(antes) == 1 {
+		bigBlind := h.Posts[slices.IndexFunc(h.Posts, func(b BlindPosted) bool { return b.Kind == BigBlind })].Player
+		switch poster := h.Player(antes[0].Player); {
+		case poster.Name == bigBlind:
+			h.AnteRule = AnteBigBlind
+		case poster.seat == h.ButtonSeat:
+			h.AnteRule = AnteButton
+		}
+	}
+	for _, ante := range antes {
+		if h.AnteRule == AnteEveryPlayer {
+			p.contributed[ante.Player] += ante.Amount
+		} else {
+			p.deadMoney += ante.Amount
+		}
+	}
+
+	// Pots are built the way the game builds them, from what everyone put in
+	pots := &Game{Players: []*Player{}, deadMoney: p.deadMoney}
+	for _, player := range h.Players {
+		contributor := NewPlayer(player.Name, 0)
+		contributor.ID = player.ID
+		contributor.bet = p.contributed[player.Name]
+		if p.folded[player.Name] {
+			contributor.PlayerStatus = Folded
+		} else if player.PlayerStatus == SittingOut {
+			contributor.PlayerStatus = SittingOut
+		}
+		pots.Players = append(pots.Players, contributor)
+	}
+	pots.AddBetsToPots()
+	h.Pots = pots.Pots
+	return nil
+}
+
+// amount reads an amount of the history, scaled to whole chips
+func (p *handParser) amount(s string) (int, error) {
+	s = strings.ReplaceAll(strings.TrimLeft(s, "$€£"), ",", "")
+	whole, fraction, _ := strings.Cut(s, ".")
+	chips, err := strconv.Atoi(whole)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q", s)
+	}
+	chips *= p.hand.Scale
+	if fraction = strings.TrimRight(fraction, "0"); fraction != "" {
+		if p.hand.Scale != 100 || len(fraction) > 2 {
+			return 0, fmt.Errorf("amount %q is not a whole number of chips", s)
+		}
+		cents, _ := strconv.Atoi((fraction + "0")[:2])
+		chips += cents
+	}
+	return chips, nil
+}
+
+// cardsIn returns the cards written between the first brackets of the text,
+// e.g. "Ah Kd" from "shows [Ah Kd] (high card Ace)"
+func cardsIn(text string) (string, error) {
+	m := cardsPart.FindStringSubmatch(text)
+	if m == nil {
+		return "", fmt.Errorf("no cards in brackets in %q", text)
+	}
+	return m[1], nil
+}
+
+// parseCards reads cards written the way hand histories do, e.g. "Ah Kd"
+func parseCards(codes string) ([]Card, error) {
+	cards := []Card{}
+	for _, code := range strings.Fields(codes) {
+		card, err := parseCard(code)
+		if err != nil {
+			return nil, err
+		}
+		cards = append(cards, card)
+	}
+	return cards, nil
+}
+
+// parseCard reads a card such as Ah or Td, see cardCodes
+func parseCard(code string) (Card, error) {
+	if code == "" {
+		return Card{}, fmt.Errorf("invalid card %q", code)
+	}
+	value := slices.Index(cardValueCodes[:], strings.ToUpper(code[:len(code)-1]))
+	if code[:len(code)-1] == "10" {
+		value = int(Ten)
+	}
+	suit := slices.Index(cardSuitCodes[:], strings.ToLower(code[len(code)-1:]))
+	if value < int(Ace) || suit < 0 {
+		return Card{}, fmt.Errorf("invalid card %q", code)
+	}
+	return Card{Suit: Suit(suit), Value: Value(value)}, nil
+}
+
+// Record returns the hand as a HandRecord, so a Replayer can play it again.
+// The deck is stacked to deal the known hole cards and board, the cards nobody
+// saw are dealt from the rest of the deck. Returns an ErrInvalidHandHistory
+// error when the same card appears twice.
+func (h ParsedHand) Record() (HandRecord, error) {
+	table := Snapshot{
+		ID:             h.Table,
+		HandNumber:     max(h.Number-1, 0),
+		GameStatus:     StartGame,
+		BigBlind:       h.BigBlind,
+		SmallBlind:     h.SmallBlind,
+		MaxSeats:       h.MaxSeats,
+		Ante:           h.Ante,
+		AnteRule:       h.AnteRule,
+		Betting:        snapshotBetting(h.Betting),
+		DealerIndex:    -1,
+		Players:        []PlayerSnapshot{},
+		Deck:           NewDeck().cards,
+		Community:      []Card{},
+		Pots:           []Pot{},
+		ActionIndex:    -1,
+		NextPlayerID:   len(h.Players),
+		ButtonSeat:     h.ButtonSeat,
+		SmallBlindSeat: -1,
+		BigBlindSeat:   -1,
+	}
+	for _, post := range h.Posts {
+		seat := h.Player(post.Player).seat
+		switch {
+		case post.Kind == SmallBlind && table.SmallBlindSeat < 0:
+			table.SmallBlindSeat = seat
+		case post.Kind == BigBlind && table.BigBlindSeat < 0:
+			table.BigBlindSeat = seat
+		case post.Kind == Straddle:
+			table.StraddleRule = StraddleUTG
+			if seat == h.ButtonSeat {
+				table.StraddleRule = StraddleMississippi
+			}
+		}
+	}
+
+	// The game deals two cards to each player in turn, starting left of the button, then the board
+	dealtIn := []*Player{}
+	for _, p := range h.Players {
+		table.Players = append(table.Players, PlayerSnapshot{
+			ID:               p.ID,
+			Name:             p.Name,
+			Money:            p.money,
+			IsReady:          true,
+			Status:           p.PlayerStatus,
+			Seat:             p.seat,
+			SittingOut:       p.sittingOut,
+			MissedSmallBlind: p.missedSmallBlind,
+			MissedBigBlind:   p.missedBigBlind,
+		})
+		if !p.sittingOut {
+			dealtIn = append(dealtIn, p)
+		}
+	}
+	first := slices.IndexFunc(dealtIn, func(p *Player) bool { return p.seat > h.ButtonSeat })
+	if first > 0 {
+		dealtIn = append(dealtIn[first:], dealtIn[:first]...)
+	}
+
+	deck := []*Card{}
+	known := map[Card]bool{}
+	stack := func(cards []Card, n int) error {
+		for i := range n {
+			if i >= len(cards) {
+				deck = append(deck, nil)
+				continue
+			}
+			if known[cards[i]] {
+				return fmt.Errorf("%w: hand #%d: %v is dealt twice", ErrInvalidHandHistory, h.Number, cards[i])
+			}
+			known[cards[i]] = true
+			deck = append(deck, &cards[i])
+		}
+		return nil
+	}
+	for _, p := range dealtIn {
+		if err := stack(p.cards, 2); err != nil {
+			return HandRecord{}, err
+		}
+	}
+	if err := stack(h.Board, 5); err != nil {
+		return HandRecord{}, err
+	}
+
+	unseen := slices.DeleteFunc(NewDeck().cards, func(c Card) bool { return known[c] })
+	cards := []Card{}
+	for _, c := range deck {
+		if c == nil {
+			c, unseen = &unseen[0], unseen[1:]
+		}
+		cards = append(cards, *c)
+	}
+	cards = append(cards, unseen...)
+
+	return HandRecord{Table: table, Actions: slices.Clone(h.Actions), Deck: cards}, nil
+}
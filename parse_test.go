@@ -0,0 +1,422 @@
+package poker
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseHandHistoryRoundTrip(t *testing.T) {
+	var out bytes.Buffer
+	g, _ := newHistoryGame(&out, "Alice", "Bob", "Charlie")
+	g.StartGame()
+	g.PreFlop()
+	g.getPlayer("Alice", -1).Raise(100, g)
+	g.getPlayer("Bob", -1).Fold(g)
+	g.getPlayer("Charlie", -1).Call(g)
+	g.getPlayer("Charlie", -1).Check(g)
+	g.getPlayer("Alice", -1).Raise(200, g)
+	g.getPlayer("Charlie", -1).Call(g)
+	g.getPlayer("Charlie", -1).Raise(400, g)
+	g.getPlayer("Alice", -1).AllIn(g)
+	g.getPlayer("Charlie", -1).Call(g)
+	first := g.HandRecord()
+	firstStacks := stacks(g)
+
+	g.NextHand()
+	g.PreFlop()
+	foldAround(g)
+
+	hands, err := ParseHandHistories(strings.NewReader("\uFEFF" + out.String()))
+	if err != nil {
+		t.Fatalf("Expected the hand histories to be read, got %v", err)
+	}
+	if len(hands) != 2 {
+		t.Fatalf("Expected 2 hands, got %d", len(hands))
+	}
+
+	hand := hands[0]
+	if hand.Number != 1 || hand.Table != "Table 1" || hand.ButtonSeat != 0 || hand.SmallBlind != 25 || hand.BigBlind != 50 || hand.Scale != 1 {
+		t.Errorf("Expected hand #1 at Table 1 with the button in seat 0 and $25/$50 blinds, got %+v", hand)
+	}
+	if want := time.Date(2024, 1, 1, 18, 0, 0, 0, time.UTC); !hand.Time.Equal(want) {
+		t.Errorf("Expected the hand to be played at %v, got %v", want, hand.Time)
+	}
+	if !reflect.DeepEqual(hand.Actions, first.Actions) {
+		t.Errorf("Expected the actions %+v, got %+v", first.Actions, hand.Actions)
+	}
+	if got := cardCodes(hand.Board); got != "[8c Qd 6h 9d Qh]" {
+		t.Errorf("Expected the board [8c Qd 6h 9d Qh], got %s", got)
+	}
+	if got := cardCodes(hand.Player("Charlie").cards); got != "[5c Kc]" {
+		t.Errorf("Expected Charlie's shown cards [5c Kc], got %s", got)
+	}
+	if len(hand.Pots) != 1 || hand.Pots[0].Amount != 2025 || len(hand.Awards) != 1 || hand.Awards[0].Winners[0] != "Alice" {
+		t.Errorf("Expected Alice to win a single pot of $2025, got %+v and %+v", hand.Pots, hand.Awards)
+	}
+
+	// The imported hand replays to the same stacks
+	record, err := hand.Record()
+	if err != nil {
+		t.Fatalf("Expected a record of the hand, got %v", err)
+	}
+	replayer, err := NewReplayer(record)
+	if err != nil {
+		t.Fatalf("Expected the hand to be replayable, got %v", err)
+	}
+	if err := replayer.Seek(replayer.Len()); err != nil {
+		t.Fatalf("Expected every action to replay, got %v", err)
+	}
+	if got := stacks(replayer.Game()); !reflect.DeepEqual(got, firstStacks) {
+		t.Errorf("Expected the replay to end with stacks %v, got %v", firstStacks, got)
+	}
+	// Charlie is out of chips, so the second hand is heads up
+	if hands[1].Number != 2 || hands[1].ButtonSeat != 1 || len(hands[1].Players) != 2 || len(hands[1].Actions) != 1 {
+		t.Errorf("Expected hand #2 to be heads up with the button in seat 1 and a fold, got %+v", hands[1])
+	}
+}
+
+func TestParseHandHistoryAnteRules(t *testing.T) {
+	for _, rule := range []AnteRule{AnteEveryPlayer, AnteBigBlind, AnteButton} {
+		var out bytes.Buffer
+		g, _ := newHistoryGame(&out, "Alice", "Bob", "Charlie")
+		g.Betting = FixedLimit{SmallBet: 50, BigBet: 100, Cap: 4}
+		g.Ante, g.AnteRule = 25, rule
+		g.StartGame()
+		g.PreFlop()
+		g.getPlayer("Alice", -1).Raise(100, g)
+		g.getPlayer("Bob", -1).Fold(g)
+		g.getPlayer("Charlie", -1).Call(g)
+		for g.GameStatus != DetermineWinner {
+			g.CurrentPlayer().Check(g)
+		}
+
+		hand, err := ParseHandHistory(out.String())
+		if err != nil {
+			t.Fatalf("Expected the %v hand to be read, got %v", rule, err)
+		}
+		if hand.AnteRule != rule || hand.Ante != 25 {
+			t.Errorf("Expected a $25 ante under the %v rule, got $%d under %v", rule, hand.Ante, hand.AnteRule)
+		}
+
+		// The blinds and bets, and the antes of every player or the one for the table
+		want := 225 + 25
+		if rule == AnteEveryPlayer {
+			want = 225 + 75
+		}
+		if len(hand.Pots) != 1 || hand.Pots[0].Amount != want {
+			t.Errorf("Expected a single pot of $%d under the %v rule, got %+v", want, rule, hand.Pots)
+		}
+
+		record, err := hand.Record()
+		if err != nil {
+			t.Fatalf("Expected a record of the %v hand, got %v", rule, err)
+		}
+		replayer, err := NewReplayer(record)
+		if err != nil {
+			t.Fatalf("Expected the %v hand to be replayable, got %v", rule, err)
+		}
+		if err := replayer.Seek(replayer.Len()); err != nil {
+			t.Fatalf("Expected every action of the %v hand to replay, got %v", rule, err)
+		}
+		if got, want := stacks(replayer.Game()), stacks(g); !reflect.DeepEqual(got, want) {
+			t.Errorf("Expected the %v hand to replay to stacks %v, got %v", rule, want, got)
+		}
+	}
+}
+
+func TestParseHandHistoryShortBigBlind(t *testing.T) {
+	var out bytes.Buffer
+	g, _ := newHistoryGame(&out, "Alice", "Bob", "Charlie")
+	g.Betting = FixedLimit{SmallBet: 50, BigBet: 100, Cap: 4}
+	g.getPlayer("Charlie", -1).money = 20
+	g.StartGame()
+	g.PreFlop()
+	g.getPlayer("Alice", -1).Call(g)
+	g.getPlayer("Bob", -1).Call(g)
+	for g.GameStatus != DetermineWinner {
+		g.CurrentPlayer().Check(g)
+	}
+	if !strings.Contains(out.String(), "Charlie: posts big blind $20\n") {
+		t.Fatalf("Expected Charlie to post the big blind short, got\n%s", out.String())
+	}
+
+	// The blinds are those of the $50/$100 stakes, not Charlie's $20
+	hand, err := ParseHandHistory(out.String())
+	if err != nil {
+		t.Fatalf("Expected the hand to be read, got %v", err)
+	}
+	if hand.SmallBlind != 25 || hand.BigBlind != 50 {
+		t.Errorf("Expected $25/$50 blinds, got $%d/$%d", hand.SmallBlind, hand.BigBlind)
+	}
+	record, err := hand.Record()
+	if err != nil {
+		t.Fatalf("Expected a record of the hand, got %v", err)
+	}
+	replayer, err := NewReplayer(record)
+	if err != nil {
+		t.Fatalf("Expected the hand to be replayable, got %v", err)
+	}
+	if err := replayer.Seek(replayer.Len()); err != nil {
+		t.Fatalf("Expected every action to replay, got %v", err)
+	}
+	if got, want := stacks(replayer.Game()), stacks(g); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected the replay to end with stacks %v, got %v", want, got)
+	}
+}
+
+func TestParseHandHistoryCashGame(t *testing.T) {
+	text := `PokerStars Hand #254174931527:  Hold'em No Limit ($0.25/$0.50 USD) - 2024/11/02 21:14:07 CET [2024/11/02 16:14:07 ET]
+Table 'Aludra IV' 6-max Seat #2 is the button
+Seat 1: grinder99 ($48.75 in chips)
+Seat 2: Dealer Joe ($50 in chips)
+Seat 3: nit_42 ($12.30 in chips)
+Seat 4: away ($50 in chips) is sitting out
+Seat 5: Joe ($61.20 in chips)
+nit_42: posts small blind $0.25
+Joe: posts big blind $0.50
+grinder99: posts small & big blinds $0.75
+*** HOLE CARDS ***
+Dealt to Joe [Jc Jd]
+grinder99: raises $1.50 to $2
+Dealer Joe: folds
+nit_42: raises $10.30 to $12.30 and is all-in
+Joe: calls $11.80
+grinder99: calls $10.30
+nit_42 said, "gl"
+*** FLOP *** [2c 7h Ks]
+Joe: checks
+grinder99: bets $5
+Joe: calls $5
+*** TURN *** [2c 7h Ks] [3d]
+Joe: bets $20
+grinder99: folds
+Uncalled bet ($20) returned to Joe
+*** RIVER *** [2c 7h Ks 3d] [Ts]
+*** SHOW DOWN ***
+Joe: shows [Jc Jd] (a pair of Jacks)
+nit_42: shows [Ah Qh] (high card Ace)
+Joe collected $9.71 from side pot
+Joe collected $35.31 from main pot
+*** SUMMARY ***
+Total pot $47.15 Main pot $37.15. Side pot $10. | Rake $2.13
+Board [2c 7h Ks 3d Ts]
+Seat 1: grinder99 folded on the Turn
+Seat 2: Dealer Joe (button) folded before Flop (didn't bet)
+Seat 3: nit_42 (small blind) showed [Ah Qh] and lost with high card Ace
+Seat 5: Joe (big blind) showed [Jc Jd] and won ($45.02) with a pair of Jacks
+`
+	hand, err := ParseHandHistory(text)
+	if err != nil {
+		t.Fatalf("Expected the hand to be read, got %v", err)
+	}
+	if hand.Scale != 100 || hand.SmallBlind != 25 || hand.BigBlind != 50 || hand.MaxSeats != 6 || hand.ButtonSeat != 1 || hand.Rake != 213 {
+		t.Errorf("Expected $0.25/$0.50 in cents at a 6-max table with the button in seat 1, got %+v", hand)
+	}
+	if len(hand.Players) != 5 || hand.Player("Dealer Joe").money != 5000 || hand.Player("nit_42").money != 1230 || hand.Player("away").PlayerStatus != SittingOut {
+		t.Errorf("Expected 5 players with their stacks in cents, got %+v", hand.Players)
+	}
+	if !hand.Player("grinder99").missedBigBlind || !hand.Player("grinder99").missedSmallBlind {
+		t.Errorf("Expected grinder99 to be posting missed blinds")
+	}
+
+	expectedActions := []Action{
+		{Player: "grinder99", Type: ActionRaise, Amount: 150},
+		{Player: "Dealer Joe", Type: ActionFold},
+		{Player: "nit_42", Type: ActionAllIn, Amount: 1205},
+		{Player: "Joe", Type: ActionCall, Amount: 1180},
+		{Player: "grinder99", Type: ActionCall, Amount: 1030},
+		{Player: "Joe", Type: ActionCheck},
+		{Player: "grinder99", Type: ActionBet, Amount: 500},
+		{Player: "Joe", Type: ActionCall, Amount: 500},
+		{Player: "Joe", Type: ActionBet, Amount: 2000},
+		{Player: "grinder99", Type: ActionFold},
+	}
+	if !reflect.DeepEqual(hand.Actions, expectedActions) {
+		t.Errorf("Expected the actions %+v, got %+v", expectedActions, hand.Actions)
+	}
+
+	// The dead small blind goes to the main pot, the flop bets to the side pot
+	expectedPots := []Pot{{Amount: 3715, Eligible: []int{3, 5}}, {Amount: 1000, Eligible: []int{5}}}
+	if !reflect.DeepEqual(hand.Pots, expectedPots) {
+		t.Errorf("Expected the pots %+v, got %+v", expectedPots, hand.Pots)
+	}
+	expectedAwards := []PotAwarded{
+		{Pot: 1, Amount: 971, Winners: []string{"Joe"}, Shares: []int{971}},
+		{Pot: 0, Amount: 3531, Winners: []string{"Joe"}, Shares: []int{3531}},
+	}
+	if !reflect.DeepEqual(hand.Awards, expectedAwards) {
+		t.Errorf("Expected the awards %+v, got %+v", expectedAwards, hand.Awards)
+	}
+	if got := cardCodes(hand.Player("nit_42").cards); got != "[Ah Qh]" {
+		t.Errorf("Expected nit_42's shown cards [Ah Qh], got %s", got)
+	}
+
+	// The replay deals the same cards, without the rake
+	record, err := hand.Record()
+	if err != nil {
+		t.Fatalf("Expected a record of the hand, got %v", err)
+	}
+	replayer, err := NewReplayer(record)
+	if err != nil {
+		t.Fatalf("Expected the hand to be replayable, got %v", err)
+	}
+	if err := replayer.Seek(replayer.Len()); err != nil {
+		t.Fatalf("Expected every action to replay, got %v", err)
+	}
+	game := replayer.Game()
+	if got := cardCodes(game.getPlayer("Joe", -1).cards); got != "[Jc Jd]" {
+		t.Errorf("Expected Joe to be dealt [Jc Jd] again, got %s", got)
+	}
+	if got := cardCodes(game.Community.cards); got != "[2c 7h Ks 3d Ts]" {
+		t.Errorf("Expected the board [2c 7h Ks 3d Ts] again, got %s", got)
+	}
+	if got := game.getPlayer("Joe", -1).money; got != 6120-1730+4715 {
+		t.Errorf("Expected Joe to end with $%d, got $%d", 6120-1730+4715, got)
+	}
+}
+
+func TestParseHandHistoryCentsStacks(t *testing.T) {
+	text := `PokerStars Hand #254174931600:  Hold'em No Limit ($1/$2 USD) - 2024/11/02 21:20:41 CET [2024/11/02 16:20:41 ET]
+Table 'Aludra IV' 6-max Seat #1 is the button
+Seat 1: Alice ($200.50 in chips)
+Seat 2: Bob ($150.25 in chips)
+Seat 3: Carol ($200 in chips)
+Bob: posts small blind $1
+Carol: posts big blind $2
+*** HOLE CARDS ***
+Alice: raises $4 to $6
+Bob: folds
+Carol: calls $4
+*** FLOP *** [2c 7h Ks]
+Carol: checks
+Alice: bets $10
+Carol: folds
+Uncalled bet ($10) returned to Alice
+Alice collected $12.35 from pot
+Alice: doesn't show hand
+*** SUMMARY ***
+Total pot $13 | Rake $0.65
+Board [2c 7h Ks]
+Seat 1: Alice (button) collected ($12.35)
+Seat 2: Bob (small blind) folded before Flop
+Seat 3: Carol (big blind) folded on the Flop
+`
+	// The stakes are whole dollars, the stacks and the rake are not
+	hand, err := ParseHandHistory(text)
+	if err != nil {
+		t.Fatalf("Expected the hand to be read, got %v", err)
+	}
+	if hand.Scale != 100 || hand.SmallBlind != 100 || hand.BigBlind != 200 || hand.Rake != 65 {
+		t.Errorf("Expected $1/$2 and the rake in cents, got %+v", hand)
+	}
+	if hand.Player("Alice").money != 20050 || hand.Player("Bob").money != 15025 || hand.Player("Carol").money != 20000 {
+		t.Errorf("Expected the stacks in cents, got %+v", hand.Players)
+	}
+	if hand.Actions[0] != (Action{Player: "Alice", Type: ActionRaise, Amount: 600}) {
+		t.Errorf("Expected Alice to raise to $6 in cents, got %+v", hand.Actions[0])
+	}
+	if len(hand.Awards) != 1 || !reflect.DeepEqual(hand.Awards[0].Shares, []int{1235}) {
+		t.Errorf("Expected Alice to collect $12.35 in cents, got %+v", hand.Awards)
+	}
+}
+
+func TestParseHandHistoryErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+	}{
+		{"not a hand", "Welcome to the table\n"},
+		{"omaha", "PokerStars Hand #1: Omaha Pot Limit ($1/$2 USD) - 2024/01/01 18:00:00 ET\n"},
+		{"no players", "PokerStars Hand #1: Hold'em No Limit ($1/$2 USD) - 2024/01/01 18:00:00 ET\nTable 'T' 6-max Seat #1 is the button\n"},
+		{"no big blind", `PokerStars Hand #1: Hold'em No Limit ($1/$2 USD) - 2024/01/01 18:00:00 ET
+Table 'T' 6-max Seat #1 is the button
+Seat 1: Alice ($100 in chips)
+Seat 2: Bob ($100 in chips)
+Alice: posts small blind $1
+*** HOLE CARDS ***
+Bob: folds
+`},
+		{"bad card", `PokerStars Hand #1: Hold'em No Limit ($1/$2 USD) - 2024/01/01 18:00:00 ET
+Table 'T' 6-max Seat #1 is the button
+Seat 1: Alice ($100 in chips)
+Seat 2: Bob ($100 in chips)
+Dealt to Alice [Ah Xx]
+`},
+	}
+	for _, tt := range tests {
+		if _, err := ParseHandHistory(tt.text); !errors.Is(err, ErrInvalidHandHistory) {
+			t.Errorf("Expected %s to fail with %v, got %v", tt.name, ErrInvalidHandHistory, err)
+		}
+	}
+
+	// Lines cut short in the middle of the cards are reported with their line number
+	complete := `PokerStars Hand #1: Hold'em No Limit ($1/$2 USD) - 2024/01/01 18:00:00 ET
+Table 'T' 6-max Seat #1 is the button
+Seat 1: Alice ($100 in chips)
+Seat 2: Carl ($100 in chips)
+Alice: posts small blind $1
+Carl: posts big blind $2
+*** HOLE CARDS ***
+Alice: calls $1
+Carl: checks
+*** FLOP *** [2c 7h Ks]
+Alice: checks
+Carl: checks
+*** TURN *** [2c 7h Ks] [3d]
+Alice: checks
+Carl: checks
+*** RIVER *** [2c 7h Ks 3d] [Ts]
+Alice: checks
+Carl: checks
+*** SHOW DOWN ***
+Carl: shows [Ah Kd] (a pair of Kings)
+Alice: mucks hand
+Carl collected $4 from pot
+*** SUMMARY ***
+Total pot $4 | Rake $0
+Board [2c 7h Ks 3d Ts]
+Seat 1: Alice (button) (small blind) mucked [Qs Jd]
+Seat 2: Carl (big blind) showed [Ah Kd] and won ($4) with a pair of Kings
+`
+	if _, err := ParseHandHistories(strings.NewReader(complete)); err != nil {
+		t.Fatalf("Expected the complete hand to be read, got %v", err)
+	}
+	for _, truncated := range []string{
+		"*** FLOP *** [2c 7h Ks]",
+		"Carl: shows [Ah Kd] (a pair of Kings)",
+		"Board [2c 7h Ks 3d Ts]",
+		"Seat 1: Alice (button) (small blind) mucked [Qs Jd]",
+	} {
+		text := strings.Replace(complete, truncated, truncated[:strings.Index(truncated, "[")+3], 1)
+		line := strings.Count(complete[:strings.Index(complete, truncated)], "\n") + 1
+		_, err := ParseHandHistories(strings.NewReader(text))
+		if !errors.Is(err, ErrInvalidHandHistory) || !strings.Contains(err.Error(), fmt.Sprintf("line %d:", line)) {
+			t.Errorf("Expected %q cut short to fail with %v on line %d, got %v", truncated, ErrInvalidHandHistory, line, err)
+		}
+	}
+
+	// A card dealt twice cannot be stacked in a deck
+	hand, err := ParseHandHistory(`PokerStars Hand #1: Hold'em No Limit ($1/$2 USD) - 2024/01/01 18:00:00 ET
+Table 'T' 6-max Seat #1 is the button
+Seat 1: Alice ($100 in chips)
+Seat 2: Bob ($100 in chips)
+Alice: posts small blind $1
+Bob: posts big blind $2
+*** HOLE CARDS ***
+Dealt to Alice [Ah Kd]
+Alice: folds
+*** SUMMARY ***
+Seat 2: Bob (big blind) mucked [Ah Qd]
+`)
+	if err != nil {
+		t.Fatalf("Expected the hand to be read, got %v", err)
+	}
+	if _, err := hand.Record(); !errors.Is(err, ErrInvalidHandHistory) {
+		t.Errorf("Expected %v for a card dealt twice, got %v", ErrInvalidHandHistory, err)
+	}
+}
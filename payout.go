@@ -0,0 +1,207 @@
+package poker
+
+import (
+	"fmt"
+	"slices"
+)
+
+// PayoutTier is the share of the prize pool paid to each place, in tenths of
+// a percent, for fields of up to MaxEntrants players
+type PayoutTier struct {
+	MaxEntrants int
+	PerMille    []int
+}
+
+// PayoutTable lists payout tiers by increasing field size
+type PayoutTable []PayoutTier
+
+// DefaultPayouts pays the winner alone in the smallest fields and about the top
+// 15% of the field in the largest. Fields larger than the last tier pay as it does.
+var DefaultPayouts = PayoutTable{
+	{3, []int{1000}},
+	{6, []int{650, 350}},
+	{10, []int{500, 300, 200}},
+	{20, []int{400, 250, 170, 110, 70}},
+	{30, []int{350, 220, 150, 110, 80, 50, 40}},
+	{50, []int{300, 200, 140, 100, 80, 60, 50, 40, 30}},
+	{100, []int{275, 175, 120, 90, 70, 55, 45, 35, 30, 20, 20, 20, 15, 15, 15}},
+}
+
+// Payouts returns the prize for each place paid in a field of entrants sharing
+// prizePool, best first. Prizes are rounded down to multiples of unit, the
+// smallest chip or currency unit paid out, and what is left over by rounding
+// goes to the winner, so the prizes always add up to the prize pool.
+func (pt PayoutTable) Payouts(entrants, prizePool, unit int) ([]int, error) {
+	if entrants < 2 {
+		return nil, fmt.Errorf("%w: payouts need at least 2 entrants, got %d", ErrNotEnoughPlayers, entrants)
+	}
+	if len(pt) == 0 {
+		return nil, fmt.Errorf("%w: the payout table has no tiers", ErrIllegalAction)
+	}
+	unit = max(unit, 1)
+
+	tier := pt[len(pt)-1]
+	for _, t := range pt {
+		if entrants <= t.MaxEntrants {
+			tier = t
+			break
+		}
+	}
+
+	places := min(len(tier.PerMille), entrants)
+	total := 0
+	for _, perMille := range tier.PerMille[:places] {
+		total += perMille
+	}
+	prizes := make([]int, places)
+	paid := 0
+	for i, perMille := range tier.PerMille[:places] {
+		prizes[i] = prizePool * perMille / total / unit * unit
+		paid += prizes[i]
+	}
+	prizes[0] += prizePool - paid
+	return prizes, nil
+}
+
+// ICM returns each player's share of the prizes under the Independent Chip
+// Model: the chance of a player finishing first is their share of the chips,
+// and the chance of finishing in each place after that is worked out the same
+// way among the players left. Players without chips get nothing.
+// The model is worked out exactly, which limits it to 20 players.
+func ICM(stacks []int, payouts []int) ([]float64, error) {
+	if len(stacks) > 20 {
+		return nil, fmt.Errorf("%w: ICM is limited to 20 players, got %d", ErrTooManyPlayers, len(stacks))
+	}
+	equity := make([]float64, len(stacks))
+	everyone := uint32(1)<<len(stacks) - 1
+	for i, stack := range stacks {
+		if stack <= 0 {
+			everyone &^= 1 << i
+		}
+	}
+
+	// finish holds the chance of the players in each set being the ones left
+	// after the others have finished in the places paid
+	finish := map[uint32]float64{everyone: 1}
+	sets := []uint32{everyone}
+	for place := 0; place < len(payouts) && len(sets) > 0; place++ {
+		next := []uint32{}
+		for _, set := range sets {
+			chance := finish[set]
+			total := 0
+			for i := range stacks {
+				if set&(1<<i) != 0 {
+					total += stacks[i]
+				}
+			}
+			for i := range stacks {
+				if set&(1<<i) == 0 {
+					continue
+				}
+				p := chance * float64(stacks[i]) / float64(total)
+				equity[i] += p * float64(payouts[place])
+				rest := set &^ (1 << i)
+				if rest == 0 {
+					continue
+				}
+				if _, ok := finish[rest]; !ok {
+					next = append(next, rest)
+				}
+				finish[rest] += p
+			}
+		}
+		sets = next
+	}
+	return equity, nil
+}
+
+// ICMChop splits the prizes still to be won by the players with the given stacks
+// according to ICM, rounded to multiples of unit
+func ICMChop(stacks []int, payouts []int, unit int) ([]int, error) {
+	prizes := payouts[:min(len(payouts), len(stacks))]
+	equity, err := ICM(stacks, prizes)
+	if err != nil {
+		return nil, err
+	}
+	return roundShares(equity, sum(prizes), unit, stacks), nil
+}
+
+// ChipChop splits the prizes still to be won by the players with the given
+// stacks: everyone is guaranteed the prize for the last of their places, and
+// the rest is shared in proportion to the chips. Amounts are rounded to
+// multiples of unit.
+func ChipChop(stacks []int, payouts []int, unit int) []int {
+	prizes := payouts[:min(len(payouts), len(stacks))]
+	guaranteed := 0
+	if len(prizes) == len(stacks) {
+		guaranteed = prizes[len(prizes)-1]
+	}
+	rest := float64(sum(prizes) - guaranteed*len(stacks))
+	chips := float64(sum(stacks))
+
+	shares := make([]float64, len(stacks))
+	for i, stack := range stacks {
+		shares[i] = float64(guaranteed) + rest*float64(stack)/chips
+	}
+	return roundShares(shares, sum(prizes), unit, stacks)
+}
+
+// roundShares rounds the shares down to multiples of unit and hands out the
+// units left over to the largest remainders, anything less than a unit going
+// to the biggest stack, so the amounts add up to total
+func roundShares(shares []float64, total, unit int, stacks []int) []int {
+	unit = max(unit, 1)
+	amounts := make([]int, len(shares))
+	paid := 0
+	for i, share := range shares {
+		amounts[i] = int(share) / unit * unit
+		paid += amounts[i]
+	}
+
+	order := make([]int, len(shares))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		ra, rb := shares[a]-float64(amounts[a]), shares[b]-float64(amounts[b])
+		switch {
+		case ra > rb:
+			return -1
+		case ra < rb:
+			return 1
+		default:
+			return stacks[b] - stacks[a]
+		}
+	})
+	for k := 0; total-paid >= unit; k = (k + 1) % len(order) {
+		amounts[order[k]] += unit
+		paid += unit
+	}
+
+	biggest := 0
+	for i, stack := range stacks {
+		if stack > stacks[biggest] {
+			biggest = i
+		}
+	}
+	amounts[biggest] += total - paid
+	return amounts
+}
+
+// sum adds up the values
+func sum(values []int) int {
+	total := 0
+	for _, v := range values {
+		total += v
+	}
+	return total
+}
+
+// Stacks returns the money each player has in front of them, in the order of Game.Players
+func (g *Game) Stacks() []int {
+	stacks := make([]int, len(g.Players))
+	for i, player := range g.Players {
+		stacks[i] = player.money + player.bet
+	}
+	return stacks
+}
@@ -0,0 +1,88 @@
+package poker
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestPayouts(t *testing.T) {
+	tests := []struct {
+		entrants, prizePool, unit int
+		expected                  []int
+	}{
+		{2, 100, 1, []int{100}},
+		{8, 1000, 5, []int{500, 300, 200}},
+		{25, 2537, 5, []int{902, 555, 380, 275, 200, 125, 100}},
+		{500, 100000, 100, []int{27500, 17500, 12000, 9000, 7000, 5500, 4500, 3500, 3000, 2000, 2000, 2000, 1500, 1500, 1500}},
+	}
+	for _, tt := range tests {
+		prizes, err := DefaultPayouts.Payouts(tt.entrants, tt.prizePool, tt.unit)
+		if err != nil {
+			t.Fatalf("Expected payouts for %d entrants, got %v", tt.entrants, err)
+		}
+		if !slices.Equal(prizes, tt.expected) {
+			t.Errorf("Expected payouts %v for %d entrants, got %v", tt.expected, tt.entrants, prizes)
+		}
+	}
+
+	if _, err := DefaultPayouts.Payouts(1, 100, 1); !errors.Is(err, ErrNotEnoughPlayers) {
+		t.Errorf("Expected a single entrant to fail with %v, got %v", ErrNotEnoughPlayers, err)
+	}
+}
+
+func TestICM(t *testing.T) {
+	equity, err := ICM([]int{5000, 3000, 2000}, []int{50, 30, 20})
+	if err != nil {
+		t.Fatalf("Expected ICM to be worked out, got %v", err)
+	}
+	expected := []float64{38.392857, 32.75, 28.857143}
+	for i := range expected {
+		if math.Abs(equity[i]-expected[i]) > 1e-6 {
+			t.Errorf("Expected equity %v, got %v", expected, equity)
+			break
+		}
+	}
+
+	// Equal stacks share the prizes equally, busted players get nothing
+	equity, _ = ICM([]int{1000, 1000, 0, 1000, 1000}, []int{500, 300, 200})
+	for i, e := range equity {
+		want := 250.0
+		if i == 2 {
+			want = 0
+		}
+		if math.Abs(e-want) > 1e-9 {
+			t.Errorf("Expected player %d to have equity %v, got %v", i, want, e)
+		}
+	}
+
+	if _, err := ICM(make([]int, 21), []int{100}); !errors.Is(err, ErrTooManyPlayers) {
+		t.Errorf("Expected 21 players to fail with %v, got %v", ErrTooManyPlayers, err)
+	}
+}
+
+func TestChops(t *testing.T) {
+	stacks := []int{5000, 3000, 2000}
+	payouts := []int{50, 30, 20, 10}
+
+	chop, err := ICMChop(stacks, payouts, 1)
+	if err != nil || !slices.Equal(chop, []int{38, 33, 29}) {
+		t.Errorf("Expected an ICM chop of [38 33 29], got %v, %v", chop, err)
+	}
+	if chop := ChipChop(stacks, payouts, 1); !slices.Equal(chop, []int{40, 32, 28}) {
+		t.Errorf("Expected a chip chop of [40 32 28], got %v", chop)
+	}
+
+	// Ties for a leftover unit and amounts smaller than the unit go to the chip leader
+	if chop := ChipChop([]int{3000, 3000, 4000}, []int{110, 60, 35}, 10); !slices.Equal(chop, []int{60, 60, 85}) {
+		t.Errorf("Expected a chip chop of [60 60 85], got %v", chop)
+	}
+}
+
+func TestGameStacks(t *testing.T) {
+	g := newStartedGame(1000, 50, "Alice", "Bob", "Charlie")
+	if stacks := g.Stacks(); !slices.Equal(stacks, []int{1000, 1000, 1000}) {
+		t.Errorf("Expected stacks of 1000, got %v", stacks)
+	}
+}
@@ -14,6 +14,7 @@ const (
 	Raised
 	AllIn
 	Thinking
+	SittingOut // Not dealt into the current hand
 )
 
 func (ps PlayerStatus) String() string {
@@ -32,6 +33,8 @@ func (ps PlayerStatus) String() string {
 		return "All In"
 	case Thinking:
 		return "Thinking"
+	case SittingOut:
+		return "Sitting Out"
 	default:
 		panic("invalid player status value")
 	}
@@ -39,6 +42,7 @@ func (ps PlayerStatus) String() string {
 
 // Player data structure
 type Player struct {
+	ID    int // Identifies the player within the game, assigned when they join
 	Name  string
 	money int
 	bet   int
@@ -46,23 +50,51 @@ type Player struct {
 	IsReady  bool
 	IsDealer bool
 	PlayerStatus
+	hasActed    bool // Whether the player has acted in the current betting round
+	actedFacing int  // The full bet level the player last acted against
+	contributed int  // Bets collected into the pots during the current hand
+	seat        int  // Seat number at the table, see Game.SitDown
+	sittingOut  bool // Whether the player is dealt out of the next hands, see Game.SitOut
+
+	// Blinds the player has to post when they are dealt in again
+	missedSmallBlind bool
+	missedBigBlind   bool
+	// Whether the player is new to the table and waits for the big blind instead
+	// of being dealt in on the button or in the small blind, without owing a blind
+	awaitingBigBlind bool
 }
 
 // NewPlayer initialises a new player who has joined the game
 func NewPlayer(name string, money int) *Player {
-	return &Player{name, money, 0, CardStack{}, false, false, Waiting}
+	return &Player{0, name, money, 0, CardStack{}, false, false, Waiting, false, 0, 0, 0, false, false, false, false}
+}
+
+// Money returns the player's balance, not counting their current bet
+func (p *Player) Money() int {
+	return p.money
+}
+
+// Seat returns the number of the seat the player sits in, counting from 0
+func (p *Player) Seat() int {
+	return p.seat
+}
+
+// inHand reports whether the player is dealt into the current hand and has not folded
+func (p *Player) inHand() bool {
+	return p.PlayerStatus != Folded && p.PlayerStatus != SittingOut
 }
 
 // Deal a card to the player's hand from the deck
-func (p *Player) Deal(d *Deck) {
+func (p *Player) Deal(d *Deck) error {
 	if p.CardStack.Count() == 2 {
-		panic("Player's hand cannot hold more than 2 cards")
+		return fmt.Errorf("%w: player %s already holds 2 cards", ErrHandFull, p.Name)
 	}
 	dealtCard, success := d.Pop()
 	if !success {
-		panic("Unable to deal to player! The deck is empty")
+		return fmt.Errorf("%w: unable to deal to player %s", ErrDeckEmpty, p.Name)
 	}
 	p.CardStack.Push(dealtCard)
+	return nil
 }
 
 // StartTurn sets the status of the player to reflect that it is their turn
@@ -71,79 +103,117 @@ func (p *Player) StartTurn() {
 }
 
 // Fold the player's hand, their folded bet will be collected in the pot when the round ends
-func (p *Player) Fold() {
+func (p *Player) Fold(g *Game) error {
+	if err := g.checkTurn(p); err != nil {
+		return err
+	}
 	p.PlayerStatus = Folded
-	fmt.Printf("Player %s folds.\n", p.Name)
+	g.actionTaken(ActionTaken{Player: p.Name, Action: ActionFold, Bet: p.bet})
+	return g.recordAction(p)
 }
 
-// Check if their current bet suffices, return whether they were allowed to check
-func (p *Player) Check(g *Game) bool {
-	success := false
-	if p.bet == g.highestBet {
-		success = true
-		p.PlayerStatus = Checked
-		fmt.Printf("Player %s checks.\n", p.Name)
-	} else {
-		fmt.Printf("Player %s cannot check.\n", p.Name)
-	}
-	return success
+// postBlind puts a forced bet in without it counting as the player's action,
+// going All In if the player cannot cover it. Returns the amount posted.
+func (p *Player) postBlind(amount int) int {
+	if amount >= p.money {
+		amount = p.money
+		p.PlayerStatus = AllIn
+	}
+	p.bet = p.bet + amount
+	p.money = p.money - amount
+	return amount
+}
+
+// postAnte puts an ante in without it counting towards the player's bet,
+// going All In if the player cannot cover it. Returns the amount posted.
+func (p *Player) postAnte(amount int) int {
+	if amount >= p.money {
+		amount = p.money
+		p.PlayerStatus = AllIn
+	}
+	p.money = p.money - amount
+	return amount
+}
+
+// Check if their current bet suffices, returning an error if they may not check
+func (p *Player) Check(g *Game) error {
+	if err := g.checkTurn(p); err != nil {
+		return err
+	}
+	if p.bet < g.highestBet {
+		return fmt.Errorf("%w: player %s cannot check facing a bet of $%d with $%d in", ErrIllegalAction, p.Name, g.highestBet, p.bet)
+	}
+	p.PlayerStatus = Checked
+	g.actionTaken(ActionTaken{Player: p.Name, Action: ActionCheck, Bet: p.bet})
+	return g.recordAction(p)
 }
 
 // AllIn sets their entire remaining money balance as their bet,
 // and adds them to a split pot if they do not have enough money for the maximum bet
-func (p *Player) AllIn(g *Game) {
-	if p.money > 0 {
-		fmt.Printf("Player %s goes All In for $%d!\n", p.Name, p.bet+p.money)
-		p.bet = p.bet + p.money
-		p.money = 0
-		p.PlayerStatus = AllIn
-		if p.bet > g.highestBet {
-			g.highestBet = p.bet
-		}
+func (p *Player) AllIn(g *Game) error {
+	if err := g.checkTurn(p); err != nil {
+		return err
+	}
+	if p.money == 0 {
+		return fmt.Errorf("%w: player %s has no money left to go All In", ErrInsufficientFunds, p.Name)
+	}
+	if _, ok := g.legalAction(p, ActionAllIn); !ok {
+		return fmt.Errorf("%w: player %s cannot go All In for $%d, check the legal actions for the allowed amounts", ErrIllegalAction, p.Name, p.money)
 	}
+	amount := p.money
+	p.bet = p.bet + amount
+	p.money = 0
+	p.PlayerStatus = AllIn
+	g.actionTaken(ActionTaken{Player: p.Name, Action: ActionAllIn, Amount: amount, Bet: p.bet})
+	return g.recordAction(p)
 }
 
 // Call the bet if they can afford it, otherwise go All In
-func (p *Player) Call(g *Game) {
-	if p.PlayerStatus != AllIn {
-		amountToCall := g.highestBet - p.bet
-		if p.money <= amountToCall {
-			p.AllIn(g)
-		} else {
-			fmt.Printf("Player %s calls $%d\n", p.Name, g.highestBet)
-			p.bet = p.bet + amountToCall
-			p.money = p.money - amountToCall
-			p.PlayerStatus = Called
-			if p.bet > g.highestBet {
-				g.highestBet = p.bet
-			}
-		}
-	} else {
-		fmt.Printf("Player %s is already All In", p.Name)
+func (p *Player) Call(g *Game) error {
+	if err := g.checkTurn(p); err != nil {
+		return err
 	}
+	if p.PlayerStatus == AllIn {
+		return fmt.Errorf("%w: player %s is already All In", ErrIllegalAction, p.Name)
+	}
+	amountToCall := g.highestBet - p.bet
+	if amountToCall <= 0 {
+		return fmt.Errorf("%w: player %s has nothing to call with $%d in, they can check instead", ErrIllegalAction, p.Name, p.bet)
+	}
+	if p.money <= amountToCall {
+		return p.AllIn(g)
+	}
+	p.bet = p.bet + amountToCall
+	p.money = p.money - amountToCall
+	p.PlayerStatus = Called
+	g.actionTaken(ActionTaken{Player: p.Name, Action: ActionCall, Amount: amountToCall, Bet: p.bet})
+	return g.recordAction(p)
 }
 
-// Raise by a specified amount if the player has suffient money.
+// Raise by a specified amount if the player has suffient money and the amount
+// is a legal bet or raise, see Game.LegalActions.
 // If it's the same as their amount of money, go All In
-// Return whether the bet was successful
-func (p *Player) Raise(amount int, g *Game) bool {
-	success := false
-	if amount < p.money {
-		p.bet = p.bet + amount
-		p.money = p.money - amount
-		success = true
-		fmt.Printf("Player %s raised by $%d\n", p.Name, amount)
+func (p *Player) Raise(amount int, g *Game) error {
+	if err := g.checkTurn(p); err != nil {
+		return err
+	}
+	if amount > p.money {
+		return fmt.Errorf("%w: player %s tried to raise by $%d with a balance of $%d, they can go All In instead and create a split pot",
+			ErrInsufficientFunds, p.Name, amount, p.money)
 	}
 	if amount == p.money {
-		p.AllIn(g)
-		success = true
+		return p.AllIn(g)
 	}
-	if amount > p.money {
-		fmt.Printf("Player %s tried to raise by %d but their balance is insufficient. You can go All In instead and create a split pot.\n", p.Name, amount)
+	if !g.allowsRaise(p, amount) {
+		return fmt.Errorf("%w: player %s cannot raise by $%d, check the legal actions for the allowed amounts", ErrIllegalAction, p.Name, amount)
 	}
-	if success && p.bet > g.highestBet {
-		g.highestBet = p.bet
+	action := ActionRaise
+	if g.highestBet == 0 {
+		action = ActionBet
 	}
-	fmt.Printf("Highest bet is %d\n", g.highestBet)
-	return success
+	p.bet = p.bet + amount
+	p.money = p.money - amount
+	p.PlayerStatus = Raised
+	g.actionTaken(ActionTaken{Player: p.Name, Action: action, Amount: amount, Bet: p.bet})
+	return g.recordAction(p)
 }
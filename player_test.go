@@ -1,6 +1,7 @@
 package poker
 
 import (
+	"errors"
 	"testing"
 )
 
@@ -51,11 +52,19 @@ func TestRaise(t *testing.T) {
 		{
 			name:          "Sufficient balance to raise",
 			initialMoney:  1000,
-			raiseAmount:   100,
-			expectedBet:   150, // Includes small blind (50)
-			expectedMoney: 850, // Deducts small blind and raise
+			raiseAmount:   150,
+			expectedBet:   200, // Includes small blind (50)
+			expectedMoney: 800, // Deducts small blind and raise
 			success:       true,
 		},
+		{
+			name:          "Raise below the minimum raise",
+			initialMoney:  1000,
+			raiseAmount:   100,
+			expectedBet:   50,  // Raising to $150 is less than a full raise of $100
+			expectedMoney: 950, // Deducts only small blind
+			success:       false,
+		},
 		{
 			name:          "Insufficient balance to raise",
 			initialMoney:  1000,
@@ -78,7 +87,8 @@ func TestRaise(t *testing.T) {
 			g.StartGame()
 			g.PreFlop()
 
-			success := p.Raise(tt.raiseAmount, g)
+			err := p.Raise(tt.raiseAmount, g)
+			success := err == nil
 
 			if success != tt.success {
 				t.Errorf("Expected success to be %v, got %v", tt.success, success)
@@ -128,7 +138,8 @@ func TestPlayerCheck(t *testing.T) {
 			} else {
 				p1.Call(g) // Call the big blind
 			}
-			result := p2.Check(g)
+			err := p2.Check(g)
+			result := err == nil
 
 			if result != tt.expectedCheck {
 				t.Errorf("Expected check result to be %v, got %v", tt.expectedCheck, result)
@@ -171,7 +182,7 @@ func TestPlayerFold(t *testing.T) {
 			p1.Call(g)                  // Call the big blind
 			p2.Raise(tt.responseBet, g) // Raise to $100
 			p1.AllIn(g)
-			p2.Fold() // No longer capturing a return value
+			p2.Fold(g) // No longer capturing a return value
 
 			if p2.money != tt.expectedMoney {
 				t.Errorf("Expected money to be %d, got %d", tt.expectedMoney, p2.money)
@@ -234,9 +245,12 @@ func TestPlayerCall(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			g := NewGame(1000, 50)
-			p := NewPlayer("TestPlayer", tt.initialMoney)
+			g.AddPlayer("TestPlayer")
+			p := g.getPlayer("TestPlayer", 0)
+			p.money = tt.initialMoney
 			p.bet = tt.responseBet
 			g.highestBet = tt.highestBet
+			g.actionIndex = 0 // It is the player's turn
 
 			p.Call(g)
 
@@ -252,3 +266,47 @@ func TestPlayerCall(t *testing.T) {
 		})
 	}
 }
+
+func TestPlayerDealErrors(t *testing.T) {
+	d := &Deck{CardStack{[]Card{{Clubs, King}, {Clubs, Queen}, {Clubs, Jack}}}}
+	p := NewPlayer("Bob", 1000)
+	p.Deal(d)
+	p.Deal(d)
+	if err := p.Deal(d); !errors.Is(err, ErrHandFull) {
+		t.Errorf("Expected %v, got %v", ErrHandFull, err)
+	}
+
+	empty := &Deck{}
+	p = NewPlayer("Alice", 1000)
+	if err := p.Deal(empty); !errors.Is(err, ErrDeckEmpty) {
+		t.Errorf("Expected %v, got %v", ErrDeckEmpty, err)
+	}
+}
+
+func TestPlayerActionErrors(t *testing.T) {
+	g := newStartedGame(1000, 50, "Alice", "Bob")
+	p1 := g.getPlayer("Alice", 0) // Small blind
+	p2 := g.getPlayer("Bob", 1)   // Big blind
+
+	if err := p1.Check(g); !errors.Is(err, ErrIllegalAction) {
+		t.Errorf("Expected checking facing a bet to fail with %v, got %v", ErrIllegalAction, err)
+	}
+	if err := p1.Raise(5000, g); !errors.Is(err, ErrInsufficientFunds) {
+		t.Errorf("Expected raising more than the balance to fail with %v, got %v", ErrInsufficientFunds, err)
+	}
+	if err := p2.Call(g); !errors.Is(err, ErrNotYourTurn) {
+		t.Errorf("Expected acting out of turn to fail with %v, got %v", ErrNotYourTurn, err)
+	}
+	if p1.bet != 25 || p1.money != 975 {
+		t.Errorf("Expected failed actions to leave Alice's bet and balance unchanged, got %d and %d", p1.bet, p1.money)
+	}
+
+	// The big blind has nothing to call once the small blind completes
+	p1.Call(g)
+	if err := p2.Call(g); !errors.Is(err, ErrIllegalAction) {
+		t.Errorf("Expected calling with nothing to call to fail with %v, got %v", ErrIllegalAction, err)
+	}
+	if actions := g.HandRecord().Actions; len(actions) != 1 || g.CurrentPlayer() != p2 {
+		t.Errorf("Expected only Alice's call to be recorded with Bob still to act, got %+v", actions)
+	}
+}
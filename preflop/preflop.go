@@ -0,0 +1,180 @@
+// Package preflop provides O(1) lookups of preflop all-in equity between the
+// 169 starting hand classes of Texas Hold'em.
+//
+// The table is produced by cmd/preflopgen, which enumerates every board for
+// every matchup with the poker evaluator, and is embedded into the binary.
+package preflop
+
+//go:generate go run ../cmd/preflopgen -o equity.bin
+
+import (
+	_ "embed"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"sync"
+
+	"poker"
+)
+
+// NumClasses is the number of distinct starting hand classes
+const NumClasses = 169
+
+// rankChars lists the ranks from highest to lowest, as used in class names
+const rankChars = "AKQJT98765432"
+
+// Class identifies a starting hand class such as "AA", "AKs" or "72o".
+// Classes are laid out on the usual 13x13 grid: pairs on the diagonal,
+// suited hands above it and offsuit hands below it, with Aces first.
+type Class uint8
+
+// rankIndex maps a card value to its row in the grid, Ace being 0 and Two 12
+func rankIndex(v poker.Value) int {
+	return (14 - int(v)) % 13
+}
+
+// ClassOf returns the class of the two hole cards
+func ClassOf(a, b poker.Card) Class {
+	hi, lo := rankIndex(a.Value), rankIndex(b.Value)
+	if hi > lo {
+		hi, lo = lo, hi
+	}
+	if hi != lo && a.Suit != b.Suit {
+		hi, lo = lo, hi // Offsuit hands live below the diagonal
+	}
+	return Class(hi*13 + lo)
+}
+
+// ParseClass parses a class name such as "AA", "AKs" or "T9o"
+func ParseClass(s string) (Class, error) {
+	if len(s) < 2 || len(s) > 3 {
+		return 0, fmt.Errorf("invalid hand class %q", s)
+	}
+	hi := strings.IndexByte(rankChars, s[0])
+	lo := strings.IndexByte(rankChars, s[1])
+	if hi < 0 || lo < 0 {
+		return 0, fmt.Errorf("invalid hand class %q: unknown rank", s)
+	}
+	if hi > lo {
+		hi, lo = lo, hi
+	}
+	switch {
+	case hi == lo && len(s) == 2:
+		return Class(hi*13 + lo), nil
+	case hi != lo && len(s) == 3 && s[2] == 's':
+		return Class(hi*13 + lo), nil
+	case hi != lo && len(s) == 3 && s[2] == 'o':
+		return Class(lo*13 + hi), nil
+	}
+	return 0, fmt.Errorf("invalid hand class %q", s)
+}
+
+// String representation of a class, e.g. "AKs"
+func (c Class) String() string {
+	row, col := int(c)/13, int(c)%13
+	switch {
+	case row == col:
+		return string([]byte{rankChars[row], rankChars[col]})
+	case row < col:
+		return string([]byte{rankChars[row], rankChars[col], 's'})
+	default:
+		return string([]byte{rankChars[col], rankChars[row], 'o'})
+	}
+}
+
+// Pair reports whether the class is a pocket pair
+func (c Class) Pair() bool {
+	return int(c)/13 == int(c)%13
+}
+
+// Suited reports whether the class is a suited non-pair hand
+func (c Class) Suited() bool {
+	return int(c)/13 < int(c)%13
+}
+
+// Combos returns the number of distinct hole card combinations in the class
+func (c Class) Combos() int {
+	switch {
+	case c.Pair():
+		return 6
+	case c.Suited():
+		return 4
+	default:
+		return 12
+	}
+}
+
+// Table holds the all-in equity of every class against every other class,
+// indexed as Table[hero][villain]. Ties count as half a win.
+type Table [NumClasses][NumClasses]float64
+
+// Binary layout: magic, version, then NumClasses*NumClasses little-endian
+// uint16 equities scaled to the full uint16 range
+const (
+	tableMagic   = "PFEQ"
+	tableVersion = 1
+	tableSize    = len(tableMagic) + 1 + NumClasses*NumClasses*2
+	equityScale  = 65535
+)
+
+// MarshalBinary encodes the table into its compact binary form
+func (t *Table) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 0, tableSize)
+	data = append(data, tableMagic...)
+	data = append(data, tableVersion)
+	for hero := range t {
+		for villain := range t[hero] {
+			eq := t[hero][villain]
+			if eq < 0 || eq > 1 {
+				return nil, fmt.Errorf("equity of %s vs %s out of range: %f", Class(hero), Class(villain), eq)
+			}
+			data = binary.LittleEndian.AppendUint16(data, uint16(eq*equityScale+0.5))
+		}
+	}
+	return data, nil
+}
+
+// UnmarshalBinary decodes a table previously encoded with MarshalBinary
+func (t *Table) UnmarshalBinary(data []byte) error {
+	if len(data) != tableSize {
+		return fmt.Errorf("equity table has %d bytes, expected %d", len(data), tableSize)
+	}
+	if string(data[:len(tableMagic)]) != tableMagic {
+		return fmt.Errorf("equity table has invalid magic %q", data[:len(tableMagic)])
+	}
+	if v := data[len(tableMagic)]; v != tableVersion {
+		return fmt.Errorf("equity table has version %d, expected %d", v, tableVersion)
+	}
+	data = data[len(tableMagic)+1:]
+	for hero := range t {
+		for villain := range t[hero] {
+			t[hero][villain] = float64(binary.LittleEndian.Uint16(data)) / equityScale
+			data = data[2:]
+		}
+	}
+	return nil
+}
+
+//go:embed equity.bin
+var embedded []byte
+
+var (
+	loadOnce sync.Once
+	table    Table
+)
+
+// Default returns the embedded equity table
+func Default() *Table {
+	loadOnce.Do(func() {
+		if err := table.UnmarshalBinary(embedded); err != nil {
+			panic("preflop: corrupt embedded equity table: " + err.Error())
+		}
+	})
+	return &table
+}
+
+// Equity returns the probability that hero wins an all-in preflop against
+// villain, counting ties as half a win
+func Equity(hero, villain Class) float64 {
+	return Default()[hero][villain]
+}
@@ -0,0 +1,108 @@
+package preflop
+
+import (
+	"math"
+	"testing"
+
+	"poker"
+)
+
+func TestClassNames(t *testing.T) {
+	seen := map[string]bool{}
+	for c := Class(0); c < NumClasses; c++ {
+		name := c.String()
+		if seen[name] {
+			t.Fatalf("duplicate class name %s", name)
+		}
+		seen[name] = true
+
+		parsed, err := ParseClass(name)
+		if err != nil {
+			t.Fatalf("unexpected error parsing %s: %v", name, err)
+		}
+		if parsed != c {
+			t.Errorf("expected %s to parse to %d, got %d", name, c, parsed)
+		}
+	}
+
+	for _, invalid := range []string{"", "A", "AAs", "AK", "AKx", "ZZ", "AKso"} {
+		if _, err := ParseClass(invalid); err == nil {
+			t.Errorf("expected an error parsing %q", invalid)
+		}
+	}
+}
+
+func TestClassOf(t *testing.T) {
+	tests := []struct {
+		a, b     poker.Card
+		expected string
+	}{
+		{poker.Card{Suit: poker.Spades, Value: poker.Ace}, poker.Card{Suit: poker.Hearts, Value: poker.Ace}, "AA"},
+		{poker.Card{Suit: poker.Spades, Value: poker.King}, poker.Card{Suit: poker.Spades, Value: poker.Ace}, "AKs"},
+		{poker.Card{Suit: poker.Clubs, Value: poker.Seven}, poker.Card{Suit: poker.Hearts, Value: poker.Two}, "72o"},
+		{poker.Card{Suit: poker.Diamonds, Value: poker.Ten}, poker.Card{Suit: poker.Diamonds, Value: poker.Nine}, "T9s"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			if got := ClassOf(tt.a, tt.b).String(); got != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestCombos(t *testing.T) {
+	total := 0
+	for c := Class(0); c < NumClasses; c++ {
+		total += c.Combos()
+	}
+	if total != 1326 {
+		t.Errorf("expected 1326 combos, got %d", total)
+	}
+}
+
+func TestEquity(t *testing.T) {
+	// Well known preflop all-in equities
+	tests := []struct {
+		hero, villain string
+		expected      float64
+	}{
+		{"AA", "KK", 0.8195},
+		{"AKs", "QQ", 0.4605},
+		{"AKo", "22", 0.4735},
+		{"AA", "72o", 0.8820},
+		{"JTs", "AKo", 0.4051},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.hero+" vs "+tt.villain, func(t *testing.T) {
+			hero, _ := ParseClass(tt.hero)
+			villain, _ := ParseClass(tt.villain)
+			if got := Equity(hero, villain); math.Abs(got-tt.expected) > 0.002 {
+				t.Errorf("expected equity %.4f, got %.4f", tt.expected, got)
+			}
+			if sum := Equity(hero, villain) + Equity(villain, hero); math.Abs(sum-1) > 0.0001 {
+				t.Errorf("expected equities to sum to 1, got %.4f", sum)
+			}
+		})
+	}
+}
+
+func TestTableRoundTrip(t *testing.T) {
+	data, err := Default().MarshalBinary()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var decoded Table
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if decoded != *Default() {
+		t.Error("expected decoded table to match the original")
+	}
+
+	if err := decoded.UnmarshalBinary(data[:len(data)-1]); err == nil {
+		t.Error("expected an error decoding a truncated table")
+	}
+}
@@ -0,0 +1,212 @@
+package poker
+
+import (
+	"fmt"
+	"log/slog"
+	"math/rand"
+	"slices"
+)
+
+// Action is a move made by a player during a hand, as recorded in a HandRecord
+type Action struct {
+	Player   string
+	Type     ActionType
+	Amount   int  // Chips added for a bet, raise or All In, the same unit taken by Player.Raise
+	Straddle bool // Whether the player posted a straddle, Type and Amount are unused then
+}
+
+// HandRecord is everything needed to play a hand again exactly as it was
+// played: the table before the hand was dealt, the seed its deck was shuffled
+// with and the actions of the players in order. See Replayer.
+type HandRecord struct {
+	Seed    int64
+	Table   Snapshot // The game just before the hand was dealt, once the button and blinds had moved
+	Actions []Action
+	Deck    []Card // Order the hand is dealt in instead of shuffling with Seed, when set, e.g. for an imported hand
+}
+
+// HandRecord returns the record of the current hand, or of the last one once
+// it is over. A game restored from a snapshot carries on with the snapshot's record.
+func (g *Game) HandRecord() HandRecord {
+	return g.record.clone()
+}
+
+// clone returns a copy of the record that shares nothing with it
+func (r HandRecord) clone() HandRecord {
+	return HandRecord{Seed: r.Seed, Table: r.Table.clone(), Actions: slices.Clone(r.Actions), Deck: slices.Clone(r.Deck)}
+}
+
+// newSeed picks the seed of the next hand's shuffle from SeedSource
+func (g *Game) newSeed() int64 {
+	if g.SeedSource != nil {
+		return g.SeedSource()
+	}
+	return rand.Int63()
+}
+
+// actionTaken records the player's action in the hand record and announces it
+func (g *Game) actionTaken(e ActionTaken) {
+	g.record.Actions = append(g.record.Actions, Action{Player: e.Player, Type: e.Action, Amount: e.Amount})
+	g.emit(e)
+}
+
+// Apply makes the player take the action, moving the hand on to the preflop
+// betting first unless the action is a straddle. Actions taken from a
+// HandRecord, or from the history of another site, can be applied one by one
+// to play a hand again.
+func (g *Game) Apply(a Action) error {
+	p := g.getPlayer(a.Player, -1)
+	if p == nil {
+		return fmt.Errorf("%w: %s is not seated in the game", ErrPlayerNotFound, a.Player)
+	}
+	if a.Straddle {
+		return p.Straddle(g)
+	}
+	if g.GameStatus == StartGame {
+		if err := g.PreFlop(); err != nil {
+			return err
+		}
+	}
+
+	switch a.Type {
+	case ActionFold:
+		return p.Fold(g)
+	case ActionCheck:
+		return p.Check(g)
+	case ActionCall:
+		return p.Call(g)
+	case ActionBet, ActionRaise:
+		return p.Raise(a.Amount, g)
+	case ActionAllIn:
+		return p.AllIn(g)
+	default:
+		return fmt.Errorf("%w: player %s cannot take an action of type %d", ErrIllegalAction, p.Name, a.Type)
+	}
+}
+
+// Replayer plays a recorded hand again, reconstructing the game at any point
+// of the hand. Position 0 is the hand just dealt with the blinds posted, and
+// position n the game after the first n actions. The preflop betting starts
+// before the first action that is not a straddle, or at the end of the record.
+type Replayer struct {
+	record   HandRecord
+	game     *Game
+	position int
+	logger   *slog.Logger // Logger of the replayed game, the default logger when nil
+}
+
+// NewReplayer creates a replayer for the hand, at position 0. Returns an
+// ErrInvalidSnapshot error or an *AuditError when the table cannot be restored.
+func NewReplayer(record HandRecord) (*Replayer, error) {
+	return newReplayer(record, nil)
+}
+
+// newReplayer creates a replayer whose game writes to the given logger
+func newReplayer(record HandRecord, logger *slog.Logger) (*Replayer, error) {
+	r := &Replayer{record: record, logger: logger}
+	if err := r.restart(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// restart deals the hand again from the recorded table and seed
+func (r *Replayer) restart() error {
+	g, err := restoreGame(r.record.Table, r.logger)
+	if err != nil {
+		return err
+	}
+	if deck := r.record.Deck; deck != nil {
+		if err := checkDeck(deck); err != nil {
+			return err
+		}
+		g.stackedDeck = slices.Clone(deck)
+	}
+	g.SeedSource = func() int64 { return r.record.Seed }
+	err = g.startHand()
+	g.SeedSource = nil
+	if err != nil {
+		return err
+	}
+	r.game, r.position = g, 0
+	return r.settle()
+}
+
+// checkDeck returns an ErrIncompleteDeck error unless the cards are a complete deck
+func checkDeck(cards []Card) error {
+	seen := map[Card]bool{}
+	for _, c := range cards {
+		if c.Suit >= Spades && c.Suit <= Clubs && c.Value >= Ace && c.Value <= King {
+			seen[c] = true
+		}
+	}
+	if len(cards) != 52 || len(seen) != 52 {
+		return fmt.Errorf("%w: cannot deal a hand from %d cards of which %d are different valid cards, expected 52", ErrIncompleteDeck, len(cards), len(seen))
+	}
+	return nil
+}
+
+// settle starts the preflop betting once every action has been replayed
+func (r *Replayer) settle() error {
+	if r.position == len(r.record.Actions) && r.game.GameStatus == StartGame {
+		return r.game.PreFlop()
+	}
+	return nil
+}
+
+// Game returns the game at the current position. Stepping back replays the
+// hand from the start in a new game, so listeners must subscribe to it again.
+func (r *Replayer) Game() *Game {
+	return r.game
+}
+
+// Position returns the number of actions replayed
+func (r *Replayer) Position() int {
+	return r.position
+}
+
+// Len returns the number of actions in the record
+func (r *Replayer) Len() int {
+	return len(r.record.Actions)
+}
+
+// Seek replays the hand up to the given position, from 0 to Len. An action
+// the game does not allow means the record does not match the hand, its error
+// is returned and the replayer stays at the last action that could be replayed.
+func (r *Replayer) Seek(position int) error {
+	if err := r.replay(position); err != nil {
+		return err
+	}
+	return r.settle()
+}
+
+// replay plays the actions up to the given position, leaving the hand before
+// the preflop betting when only straddles have been replayed
+func (r *Replayer) replay(position int) error {
+	if position < 0 || position > len(r.record.Actions) {
+		return fmt.Errorf("%w: position %d is outside the %d actions of the hand", ErrIllegalAction, position, len(r.record.Actions))
+	}
+	if position < r.position {
+		if err := r.restart(); err != nil {
+			return err
+		}
+	}
+	for r.position < position {
+		action := r.record.Actions[r.position]
+		if err := r.game.Apply(action); err != nil {
+			return fmt.Errorf("replaying action %d of player %s: %w", r.position+1, action.Player, err)
+		}
+		r.position++
+	}
+	return nil
+}
+
+// Forward replays the next action
+func (r *Replayer) Forward() error {
+	return r.Seek(r.position + 1)
+}
+
+// Back steps back to before the last action replayed
+func (r *Replayer) Back() error {
+	return r.Seek(r.position - 1)
+}
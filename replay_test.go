@@ -0,0 +1,149 @@
+package poker
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// playRecordedHand plays a hand with a straddle, raises, a fold and a showdown,
+// returning the game at every step: dealt, then after each action
+func playRecordedHand(t *testing.T) (*Game, []Snapshot) {
+	t.Helper()
+	g := newSeatedGame(1000, 50, "Alice", "Bob", "Charlie", "Dave")
+	g.StraddleRule = StraddleUTG
+	g.StartGame()
+	steps := []Snapshot{g.Snapshot()}
+	actions := []func() error{
+		func() error { return g.getPlayer("Dave", -1).Straddle(g) },
+		func() error {
+			g.PreFlop()
+			return g.getPlayer("Alice", -1).Raise(300, g)
+		},
+		func() error { return g.getPlayer("Bob", -1).Fold(g) },
+		func() error { return g.getPlayer("Charlie", -1).Call(g) },
+		func() error { return g.getPlayer("Dave", -1).Call(g) },
+	}
+	for g.GameStatus != DetermineWinner {
+		if len(actions) > 0 {
+			if err := actions[0](); err != nil {
+				t.Fatalf("Expected action %d to be taken, got %v", len(steps), err)
+			}
+			actions = actions[1:]
+		} else if err := g.CurrentPlayer().Check(g); err != nil {
+			t.Fatalf("Expected %s to check, got %v", g.CurrentPlayer().Name, err)
+		}
+		steps = append(steps, g.Snapshot())
+	}
+	return g, steps
+}
+
+func TestHandRecord(t *testing.T) {
+	g, steps := playRecordedHand(t)
+	record := g.HandRecord()
+
+	expected := []Action{
+		{Player: "Dave", Straddle: true},
+		{Player: "Alice", Type: ActionRaise, Amount: 300},
+		{Player: "Bob", Type: ActionFold},
+		{Player: "Charlie", Type: ActionCall, Amount: 250},
+		{Player: "Dave", Type: ActionCall, Amount: 200},
+	}
+	if len(record.Actions) != len(steps)-1 || !reflect.DeepEqual(record.Actions[:len(expected)], expected) {
+		t.Errorf("Expected the actions to start with %v, got %v", expected, record.Actions)
+	}
+	if record.Table.HandNumber != 0 || len(record.Table.Players) != 4 {
+		t.Errorf("Expected the table before the first hand, got hand %d with %d players", record.Table.HandNumber, len(record.Table.Players))
+	}
+}
+
+func TestReplayer(t *testing.T) {
+	g, steps := playRecordedHand(t)
+	replayer, err := NewReplayer(g.HandRecord())
+	if err != nil {
+		t.Fatalf("Expected the hand to be replayed, got %v", err)
+	}
+	if replayer.Len() != len(steps)-1 {
+		t.Fatalf("Expected %d actions, got %d", len(steps)-1, replayer.Len())
+	}
+
+	// Every step forward matches the hand as it was played
+	for i, want := range steps {
+		if i > 0 {
+			if err := replayer.Forward(); err != nil {
+				t.Fatalf("Expected action %d to be replayed, got %v", i, err)
+			}
+		}
+		if got := replayer.Game().Snapshot(); !reflect.DeepEqual(got, want) {
+			t.Fatalf("Expected the game after %d actions to match, got %+v, want %+v", i, got, want)
+		}
+	}
+	if err := replayer.Forward(); !errors.Is(err, ErrIllegalAction) {
+		t.Errorf("Expected stepping past the end to fail with %v, got %v", ErrIllegalAction, err)
+	}
+
+	// And so does every step back
+	for i := len(steps) - 2; i >= 0; i-- {
+		if err := replayer.Back(); err != nil {
+			t.Fatalf("Expected to step back to action %d, got %v", i, err)
+		}
+		if got := replayer.Game().Snapshot(); !reflect.DeepEqual(got, steps[i]) || replayer.Position() != i {
+			t.Fatalf("Expected the game after %d actions to match, got position %d", i, replayer.Position())
+		}
+	}
+	if err := replayer.Seek(3); err != nil || !reflect.DeepEqual(replayer.Game().Snapshot(), steps[3]) {
+		t.Errorf("Expected to seek to action 3, got %v", err)
+	}
+}
+
+func TestReplayLaterHand(t *testing.T) {
+	g := newStartedGame(1000, 50, "Alice", "Bob", "Charlie")
+	foldAround(g)
+	bust(g, "Alice")
+	g.NextHand()
+	g.PreFlop()
+	g.CurrentPlayer().Raise(100, g)
+	g.CurrentPlayer().Call(g)
+	want := g.Snapshot()
+
+	replayer, err := NewReplayer(g.HandRecord())
+	if err != nil {
+		t.Fatalf("Expected the hand to be replayed, got %v", err)
+	}
+	if err := replayer.Seek(replayer.Len()); err != nil {
+		t.Fatalf("Expected every action to be replayed, got %v", err)
+	}
+	if got := replayer.Game().Snapshot(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected the replayed hand to match, got %+v, want %+v", got, want)
+	}
+}
+
+func TestReplayMismatch(t *testing.T) {
+	g, _ := playRecordedHand(t)
+	record := g.HandRecord()
+	record.Actions[2] = Action{Player: "Charlie", Type: ActionFold}
+
+	replayer, _ := NewReplayer(record)
+	if err := replayer.Seek(4); !errors.Is(err, ErrNotYourTurn) {
+		t.Errorf("Expected an action out of turn to fail with %v, got %v", ErrNotYourTurn, err)
+	}
+	if replayer.Position() != 2 {
+		t.Errorf("Expected the replay to stop at action 2, got %d", replayer.Position())
+	}
+}
+
+func TestSeedSource(t *testing.T) {
+	games := []*Game{}
+	for range 2 {
+		g := newSeatedGame(1000, 50, "Alice", "Bob", "Charlie")
+		g.SeedSource = func() int64 { return 7 }
+		g.StartGame()
+		games = append(games, g)
+	}
+	if !reflect.DeepEqual(games[0].Players[0].cards, games[1].Players[0].cards) || !reflect.DeepEqual(games[0].Deck.cards, games[1].Deck.cards) {
+		t.Errorf("Expected the same seed to deal the same cards, got %v and %v", games[0].Players[0].cards, games[1].Players[0].cards)
+	}
+	if seed := games[0].HandRecord().Seed; seed != 7 {
+		t.Errorf("Expected the hand to record seed 7, got %d", seed)
+	}
+}
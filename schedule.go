@@ -0,0 +1,168 @@
+package poker
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// Clock tells the time to a BlindSchedule, so tests can simulate hours of play instantly
+type Clock interface {
+	Now() time.Time
+}
+
+// systemClock is the wall clock
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// Level of a blind schedule. A level lasts for Duration, for a number of Hands,
+// or until whichever of the two comes first when both are set. The last level
+// of a schedule lasts until the end of the game.
+type Level struct {
+	BigBlind int
+	Ante     int
+	Duration time.Duration // How long the level lasts, zero if it is counted by hands
+	Hands    int           // How many hands the level lasts, zero if it is timed
+	Break    time.Duration // Break taken after the level, zero for none
+	ColorUp  int           // Smallest chip left in play after the break, zero to keep every chip
+}
+
+// BlindSchedule raises the blinds and antes of a game level by level.
+// Attach it with Game.Schedule, the game then takes the big blind and ante of
+// the current level at the start of every hand.
+type BlindSchedule struct {
+	Levels []Level
+	clock  Clock
+
+	level        int       // Index of the current level
+	levelStart   time.Time // When the current level started, or will once the break is over
+	handsInLevel int       // Hands started during the current level
+	announced    int       // Index of the last level the game has played
+	started      bool
+}
+
+// NewBlindSchedule creates a schedule with the given levels, using clock to tell
+// the time or the system clock if it is nil
+func NewBlindSchedule(clock Clock, levels ...Level) *BlindSchedule {
+	if clock == nil {
+		clock = systemClock{}
+	}
+	return &BlindSchedule{Levels: levels, clock: clock}
+}
+
+// Level returns the number of the current level, starting from 1
+func (s *BlindSchedule) Level() int {
+	return s.level + 1
+}
+
+// Current returns the current level
+func (s *BlindSchedule) Current() Level {
+	return s.Levels[s.level]
+}
+
+// OnBreak reports whether the game is on a break between levels
+func (s *BlindSchedule) OnBreak() bool {
+	return s.started && s.clock.Now().Before(s.levelStart)
+}
+
+// Remaining returns how long is left of the current level, or of the break before
+// it when on a break. Levels counted by hands and the last level have no end, so
+// zero is returned for them.
+func (s *BlindSchedule) Remaining() time.Duration {
+	now := s.clock.Now()
+	if now.Before(s.levelStart) {
+		return s.levelStart.Sub(now)
+	}
+	level := s.Current()
+	if s.level == len(s.Levels)-1 || level.Duration == 0 {
+		return 0
+	}
+	return max(s.levelStart.Add(level.Duration).Sub(now), 0)
+}
+
+// start begins the first level
+func (s *BlindSchedule) start() {
+	s.level = 0
+	s.levelStart = s.clock.Now()
+	s.handsInLevel = 0
+	s.announced = -1
+	s.started = true
+}
+
+// advance moves on past every level that has ended by now, returning them.
+// Breaks are taken straight after their level ends, so the next level starts
+// once the break is over.
+func (s *BlindSchedule) advance() []Level {
+	now := s.clock.Now()
+	ended := []Level{}
+	for s.level < len(s.Levels)-1 && !now.Before(s.levelStart) {
+		level := s.Current()
+		var end time.Time
+		if level.Duration > 0 && !now.Before(s.levelStart.Add(level.Duration)) {
+			end = s.levelStart.Add(level.Duration)
+		} else if level.Hands > 0 && s.handsInLevel >= level.Hands {
+			end = now
+		} else {
+			break
+		}
+
+		ended = append(ended, level)
+		s.level++
+		s.levelStart = end.Add(level.Break)
+		s.handsInLevel = 0
+	}
+	return ended
+}
+
+// applySchedule brings the game up to date with its blind schedule before a hand
+// is dealt: chips are colored up at the breaks that have started, and the blinds
+// and antes of the current level are set. Returns an ErrOnBreak error while
+// the game is on a break.
+func (g *Game) applySchedule(starting bool) error {
+	s := g.Schedule
+	if s == nil {
+		return nil
+	}
+	if starting {
+		s.start()
+	}
+
+	for _, ended := range s.advance() {
+		if ended.ColorUp > 0 {
+			g.colorUp(ended.ColorUp)
+		}
+	}
+	if s.OnBreak() {
+		return fmt.Errorf("%w: level %d starts in %v", ErrOnBreak, s.Level(), s.Remaining().Round(time.Second))
+	}
+
+	g.BigBlind = s.Current().BigBlind
+	g.Ante = s.Current().Ante
+	if s.announced != s.level {
+		s.announced = s.level
+		g.emit(LevelStarted{Level: s.Level(), BigBlind: g.BigBlind, Ante: g.Ante})
+	}
+	s.handsInLevel++
+	return nil
+}
+
+// colorUp removes the chips smaller than chip from play, rounding every stack
+// to the nearest multiple of it. Nobody is colored out of the game, a stack too
+// small to round up still gets one chip.
+func (g *Game) colorUp(chip int) {
+	g.log().Info("coloring up", slog.Int("chip", chip))
+	for i := range g.Players {
+		player := g.Players[i]
+		before := player.money
+		player.money = (player.money + chip/2) / chip * chip
+		if player.money == 0 && before > 0 {
+			player.money = chip
+		}
+		if player.money != before {
+			g.emit(ChipsColoredUp{Player: player.Name, Before: before, After: player.money})
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package poker
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// fakeClock is a clock that only moves when told to
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+// playHand folds the hand around to the big blind and starts the next one
+func playHand(g *Game) error {
+	g.PreFlop()
+	for g.CurrentPlayer() != nil {
+		g.CurrentPlayer().Fold(g)
+	}
+	return g.NextHand()
+}
+
+func TestBlindScheduleTimed(t *testing.T) {
+	clock := &fakeClock{time.Date(2024, 1, 1, 18, 0, 0, 0, time.UTC)}
+	g := newSeatedGame(1000, 0, "Alice", "Bob")
+	g.Schedule = NewBlindSchedule(clock,
+		Level{BigBlind: 50, Duration: 15 * time.Minute},
+		Level{BigBlind: 100, Ante: 10, Duration: 15 * time.Minute, Break: 5 * time.Minute, ColorUp: 25},
+		Level{BigBlind: 200, Ante: 25},
+	)
+	recorder := &eventRecorder{}
+	g.Subscribe(recorder)
+	g.SetAuditor(NewAuditor(func(err error) { t.Error(err) }))
+	if err := g.StartGame(); err != nil {
+		t.Fatalf("Expected the game to start, got %v", err)
+	}
+	if g.BigBlind != 50 || g.Schedule.Level() != 1 || g.Schedule.Remaining() != 15*time.Minute {
+		t.Errorf("Expected level 1 with a big blind of 50 and 15m left, got level %d with %d and %v",
+			g.Schedule.Level(), g.BigBlind, g.Schedule.Remaining())
+	}
+
+	// The blinds stay the same until the level is over
+	clock.now = clock.now.Add(10 * time.Minute)
+	playHand(g)
+	if g.BigBlind != 50 {
+		t.Errorf("Expected the big blind to stay at 50, got %d", g.BigBlind)
+	}
+	clock.now = clock.now.Add(6 * time.Minute)
+	playHand(g)
+	if g.BigBlind != 100 || g.Ante != 10 || g.Schedule.Level() != 2 || g.Schedule.Remaining() != 14*time.Minute {
+		t.Errorf("Expected level 2 with a big blind of 100 and ante of 10 and 14m left, got level %d with %d and %d and %v",
+			g.Schedule.Level(), g.BigBlind, g.Ante, g.Schedule.Remaining())
+	}
+
+	// The break starts as soon as level 2 is over
+	clock.now = clock.now.Add(15 * time.Minute)
+	g.Players[0].money += 12 // Make the stacks odd, as if the hands had been played
+	g.Players[1].money -= 12
+	if err := playHand(g); !errors.Is(err, ErrOnBreak) {
+		t.Fatalf("Expected %v, got %v", ErrOnBreak, err)
+	}
+	if !g.Schedule.OnBreak() || g.Schedule.Remaining() != 4*time.Minute {
+		t.Errorf("Expected a break with 4m left, got %v and %v", g.Schedule.OnBreak(), g.Schedule.Remaining())
+	}
+	for _, player := range g.Players {
+		if player.money%25 != 0 {
+			t.Errorf("Expected %s's stack to be colored up to 25s, got %d", player.Name, player.money)
+		}
+	}
+
+	clock.now = clock.now.Add(4 * time.Minute)
+	if err := g.NextHand(); err != nil {
+		t.Fatalf("Expected the next hand to start after the break, got %v", err)
+	}
+	if g.BigBlind != 200 || g.Ante != 25 || g.Schedule.Level() != 3 || g.Schedule.Remaining() != 0 {
+		t.Errorf("Expected the last level with a big blind of 200 and ante of 25, got level %d with %d and %d and %v",
+			g.Schedule.Level(), g.BigBlind, g.Ante, g.Schedule.Remaining())
+	}
+
+	levels := []int{}
+	for _, e := range recorder.events {
+		if e, ok := e.(LevelStarted); ok {
+			levels = append(levels, e.Level)
+		}
+	}
+	if len(levels) != 3 || levels[0] != 1 || levels[1] != 2 || levels[2] != 3 {
+		t.Errorf("Expected levels 1, 2 and 3 to start, got %v", levels)
+	}
+}
+
+func TestBlindScheduleHands(t *testing.T) {
+	g := newSeatedGame(1000, 0, "Alice", "Bob", "Charlie")
+	g.Schedule = NewBlindSchedule(&fakeClock{},
+		Level{BigBlind: 20, Hands: 2},
+		Level{BigBlind: 40, Hands: 3},
+		Level{BigBlind: 80},
+	)
+	g.StartGame()
+
+	expected := []int{20, 20, 40, 40, 40, 80, 80}
+	for hand, bigBlind := range expected {
+		if g.BigBlind != bigBlind {
+			t.Errorf("Expected a big blind of %d in hand %d, got %d", bigBlind, hand+1, g.BigBlind)
+		}
+		if err := playHand(g); err != nil {
+			t.Fatalf("Expected hand %d to follow, got %v", hand+2, err)
+		}
+	}
+}
+
+func TestBlindScheduleSkipsLevels(t *testing.T) {
+	clock := &fakeClock{}
+	g := newSeatedGame(1000, 0, "Alice", "Bob")
+	g.Schedule = NewBlindSchedule(clock,
+		Level{BigBlind: 20, Duration: 20 * time.Minute},
+		Level{BigBlind: 40, Duration: 20 * time.Minute, Break: 10 * time.Minute},
+		Level{BigBlind: 60, Duration: 20 * time.Minute},
+		Level{BigBlind: 100, Duration: 20 * time.Minute},
+	)
+	g.StartGame()
+
+	// An hour and a quarter later the break is over and the game is in the third level's
+	// last five minutes
+	clock.now = clock.now.Add(75 * time.Minute)
+	if err := playHand(g); err != nil {
+		t.Fatalf("Expected the next hand to start, got %v", err)
+	}
+	if g.BigBlind != 100 || g.Schedule.Level() != 4 {
+		t.Errorf("Expected level 4 with a big blind of 100, got level %d with %d", g.Schedule.Level(), g.BigBlind)
+	}
+}
+
+func TestColorUp(t *testing.T) {
+	g := newSeatedGame(1000, 50, "Alice", "Bob", "Charlie", "Dave")
+	for i, money := range []int{37, 38, 5, 1000} {
+		g.Players[i].money = money
+	}
+	g.colorUp(25)
+
+	expected := []int{25, 50, 25, 1000}
+	for i, player := range g.Players {
+		if player.money != expected[i] {
+			t.Errorf("Expected %s to have %d, got %d", player.Name, expected[i], player.money)
+		}
+	}
+}
@@ -0,0 +1,203 @@
+package poker
+
+import (
+	"fmt"
+	"log/slog"
+)
+
+// SitDown seats a player at the given seat, numbered from 0 up to MaxSeats,
+// with a buy-in between MinBuyIn and MaxBuyIn. A player who sits down once the
+// game has started owes the big blind: they post it when they are dealt in
+// from the next hand, or come in when the big blind reaches them, but never
+// on the button or in the small blind.
+func (g *Game) SitDown(name string, seat, buyIn int) error {
+	if seat < 0 || seat >= g.seatCount() {
+		return fmt.Errorf("%w: seat %d does not exist at a table of %d seats", ErrSeatUnavailable, seat, g.seatCount())
+	}
+	if index := g.playerAtSeat(seat); index >= 0 {
+		return fmt.Errorf("%w: seat %d is taken by player %s", ErrSeatUnavailable, seat, g.Players[index].Name)
+	}
+	if err := g.checkBuyIn(name, buyIn); err != nil {
+		return err
+	}
+	return g.addPlayer(name, seat, buyIn, g.HandNumber > 0)
+}
+
+// checkBuyIn returns an ErrInvalidBuyIn error unless the player may have money
+// between MinBuyIn and MaxBuyIn in front of them
+func (g *Game) checkBuyIn(name string, money int) error {
+	if money <= 0 || money < g.MinBuyIn {
+		return fmt.Errorf("%w: player %s needs at least $%d to sit down, got $%d", ErrInvalidBuyIn, name, max(g.MinBuyIn, 1), money)
+	}
+	if g.MaxBuyIn > 0 && money > g.MaxBuyIn {
+		return fmt.Errorf("%w: player %s cannot have more than $%d in front of them, got $%d", ErrInvalidBuyIn, name, g.MaxBuyIn, money)
+	}
+	return nil
+}
+
+// emptySeat returns the lowest empty seat, or -1 when the table is full
+func (g *Game) emptySeat() int {
+	for seat := range max(g.MaxSeats, 1) {
+		if g.playerAtSeat(seat) < 0 {
+			return seat
+		}
+	}
+	return -1
+}
+
+// seatAfterBigBlind returns the first empty seat after the big blind, where a
+// new player is due the big blind next, or -1 when the table is full
+func (g *Game) seatAfterBigBlind() int {
+	for offset := 1; offset <= g.seatCount(); offset++ {
+		if seat := (g.bigBlindSeat + offset) % g.seatCount(); g.playerAtSeat(seat) < 0 {
+			return seat
+		}
+	}
+	return -1
+}
+
+// PlayerAt returns the player sitting in the seat, or nil if it is empty
+func (g *Game) PlayerAt(seat int) *Player {
+	if index := g.playerAtSeat(seat); index >= 0 {
+		return g.Players[index]
+	}
+	return nil
+}
+
+// Leave takes a player out of the game between hands, returning the money they
+// leave with. Their seat is free for the next player to sit down, while the
+// button and blinds move past it.
+func (g *Game) Leave(name string) (int, error) {
+	if g.handInProgress() {
+		return 0, g.wrongState(fmt.Sprintf("let player %s leave", name))
+	}
+	for i := range g.Players {
+		if g.Players[i].Name == name {
+			money := g.Players[i].money
+			g.removePlayer(i)
+			g.emit(PlayerLeft{Player: name, Money: money})
+			return money, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %s is not seated in the game", ErrPlayerNotFound, name)
+}
+
+// SitOut deals the player out from the next hand on, keeping their seat. While
+// they sit out they miss the blinds passing their seat, which they post when
+// they sit in again.
+func (g *Game) SitOut(name string) error {
+	p := g.getPlayer(name, -1)
+	if p == nil {
+		return fmt.Errorf("%w: %s is not seated in the game", ErrPlayerNotFound, name)
+	}
+	if !p.sittingOut {
+		p.sittingOut = true
+		g.emit(PlayerSatOut{Player: name})
+	}
+	return nil
+}
+
+// SitIn deals a player who has been sitting out back in from the next hand.
+// A missed big blind is posted live and counts towards their bet, a missed
+// small blind is dead money for the pot. A player coming back on the button or
+// in the small blind waits until the button has passed them.
+func (g *Game) SitIn(name string) error {
+	p := g.getPlayer(name, -1)
+	if p == nil {
+		return fmt.Errorf("%w: %s is not seated in the game", ErrPlayerNotFound, name)
+	}
+	if p.sittingOut {
+		p.sittingOut = false
+		g.emit(PlayerSatIn{Player: name})
+	}
+	return nil
+}
+
+// Rebuy adds money to a player's stack, to buy back in or top up their stack,
+// as long as it stays within MaxBuyIn. Players cannot add money while they are
+// playing a hand, only between hands or while they are not dealt in.
+func (g *Game) Rebuy(name string, amount int) error {
+	p := g.getPlayer(name, -1)
+	if p == nil {
+		return fmt.Errorf("%w: %s is not seated in the game", ErrPlayerNotFound, name)
+	}
+	if g.handInProgress() && p.PlayerStatus != SittingOut {
+		return g.wrongState(fmt.Sprintf("let player %s rebuy during a hand", name))
+	}
+	if amount <= 0 {
+		return fmt.Errorf("%w: player %s cannot rebuy for $%d", ErrInvalidBuyIn, name, amount)
+	}
+	if err := g.checkBuyIn(name, p.money+amount); err != nil {
+		return err
+	}
+	p.money += amount
+	g.emit(ChipsAdded{Player: name, Amount: amount, Money: p.money})
+	return nil
+}
+
+// waitForBigBlind deals out the players who owe a blind, or are awaiting the
+// big blind, and would be dealt in on the button or in the small blind, unless
+// that leaves fewer than two players in the hand. Heads-up every player is
+// dealt in.
+func (g *Game) waitForBigBlind() {
+	defer func() {
+		for _, player := range g.Players {
+			if player.PlayerStatus != SittingOut {
+				player.awaitingBigBlind = false
+			}
+		}
+	}()
+	if g.headsUp() {
+		return
+	}
+	waiting := []int{}
+	dealtIn := 0
+	for i := range g.Players {
+		player := g.Players[i]
+		if player.PlayerStatus == SittingOut {
+			continue
+		}
+		waits := player.missedBigBlind || player.missedSmallBlind || player.awaitingBigBlind
+		if waits && player.seat != g.bigBlindSeat && (player.seat == g.buttonSeat || player.seat == g.smallBlindSeat) {
+			waiting = append(waiting, i)
+		} else {
+			dealtIn++
+		}
+	}
+	if dealtIn < 2 {
+		return
+	}
+	for _, i := range waiting {
+		g.Players[i].PlayerStatus = SittingOut
+		g.log().Info("player waits for the big blind", slog.String("player", g.Players[i].Name))
+	}
+}
+
+// postMissedBlinds collects the blinds owed by the players dealt in, once the
+// blinds have been posted. A missed big blind is live, a missed small blind is
+// dead money for the main pot. Players who post a blind in their position owe
+// nothing more. Returns the dead money posted.
+func (g *Game) postMissedBlinds() int {
+	dead := 0
+	for offset := 1; offset <= len(g.Players); offset++ {
+		player := g.Players[(g.DealerIndex+offset)%len(g.Players)]
+		if player.PlayerStatus == SittingOut {
+			continue
+		}
+		if player.seat != g.smallBlindSeat && player.seat != g.bigBlindSeat {
+			if player.missedBigBlind && player.money > 0 {
+				posted := player.postBlind(g.BigBlind)
+				g.emit(BlindPosted{Player: player.Name, Kind: BigBlind, Amount: posted})
+			}
+			if player.missedSmallBlind && player.money > 0 {
+				posted := player.postAnte(g.smallBlind())
+				g.deadMoney += posted
+				dead += posted
+				g.emit(BlindPosted{Player: player.Name, Kind: DeadSmallBlind, Amount: posted})
+			}
+		}
+		player.missedSmallBlind = false
+		player.missedBigBlind = false
+	}
+	return dead
+}
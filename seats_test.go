@@ -0,0 +1,297 @@
+package poker
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSitDown(t *testing.T) {
+	game := NewGame(1000, 50)
+	game.MaxSeats = 4
+	game.MinBuyIn, game.MaxBuyIn = 500, 2000
+
+	if err := game.SitDown("Alice", 2, 800); err != nil {
+		t.Fatalf("Expected Alice to sit down, got %v", err)
+	}
+	if player := game.PlayerAt(2); player == nil || player.Name != "Alice" || player.Seat() != 2 || player.money != 800 {
+		t.Errorf("Expected Alice in seat 2 with $800, got %+v", player)
+	}
+
+	tests := []struct {
+		seat, buyIn int
+		err         error
+	}{
+		{2, 800, ErrSeatUnavailable},
+		{4, 800, ErrSeatUnavailable},
+		{-1, 800, ErrSeatUnavailable},
+		{1, 400, ErrInvalidBuyIn},
+		{1, 2500, ErrInvalidBuyIn},
+	}
+	for _, tt := range tests {
+		if err := game.SitDown("Bob", tt.seat, tt.buyIn); !errors.Is(err, tt.err) {
+			t.Errorf("Expected sitting down in seat %d for $%d to fail with %v, got %v", tt.seat, tt.buyIn, tt.err, err)
+		}
+	}
+
+	// Players added with the starting money fill the lowest empty seats
+	for _, name := range []string{"Bob", "Charlie", "Dave"} {
+		if err := game.AddPlayer(name); err != nil {
+			t.Fatalf("Expected %s to join, got %v", name, err)
+		}
+	}
+	for seat, name := range []string{"Bob", "Charlie", "Alice", "Dave"} {
+		if player := game.PlayerAt(seat); player == nil || player.Name != name {
+			t.Errorf("Expected %s in seat %d, got %+v", name, seat, player)
+		}
+	}
+	if err := game.AddPlayer("Eve"); !errors.Is(err, ErrTooManyPlayers) {
+		t.Errorf("Expected joining a full table to fail with %v, got %v", ErrTooManyPlayers, err)
+	}
+}
+
+func TestSeatsStayFixed(t *testing.T) {
+	game := NewGame(1000, 50)
+	game.Initialise()
+	game.SitDown("Alice", 1, 1000)
+	game.SitDown("Bob", 4, 1000)
+	game.SitDown("Charlie", 7, 1000)
+	game.SitDown("Dave", 0, 1000)
+	for i := range game.Players {
+		game.Players[i].IsReady = true
+	}
+	game.StartGame()
+
+	// Dave deals from the lowest seat, Alice posts the small blind and Bob the big blind
+	if names := playerNames(game.Players); names[0] != "Dave" || !game.Players[0].IsDealer {
+		t.Fatalf("Expected the players in seat order with Dave dealing, got %v", names)
+	}
+	if game.PlayerAt(1).bet != 25 || game.PlayerAt(4).bet != 50 {
+		t.Errorf("Expected the blinds in seats 1 and 4, got %d and %d", game.PlayerAt(1).bet, game.PlayerAt(4).bet)
+	}
+
+	// Eliminating a player does not move anyone else
+	foldAround(game)
+	bust(game, "Alice")
+	game.NextHand()
+	for seat, name := range map[int]string{0: "Dave", 4: "Bob", 7: "Charlie"} {
+		if player := game.PlayerAt(seat); player == nil || player.Name != name {
+			t.Errorf("Expected %s to stay in seat %d, got %+v", name, seat, player)
+		}
+	}
+	if game.PlayerAt(1) != nil {
+		t.Errorf("Expected seat 1 to be empty, got %+v", game.PlayerAt(1))
+	}
+}
+
+func TestJoinMidSession(t *testing.T) {
+	// Alice deals, Bob posts the small blind and Charlie the big blind
+	game := newStartedGame(1000, 50, "Alice", "Bob", "Charlie", "Dave")
+	if err := game.SitDown("Eve", 5, 1000); err != nil {
+		t.Fatalf("Expected Eve to sit down during the hand, got %v", err)
+	}
+	eve := game.PlayerAt(5)
+	if eve.PlayerStatus != SittingOut || eve.Count() != 0 {
+		t.Errorf("Expected Eve to wait for the next hand, got %v with %d cards", eve.PlayerStatus, eve.Count())
+	}
+	foldAround(game)
+	if game.GameStatus != DetermineWinner {
+		t.Fatalf("Expected the hand to be over without Eve, got %v", game.GameStatus)
+	}
+
+	// Bob deals and Dave has the big blind, Eve posts a live big blind to come in
+	game.NextHand()
+	eve = game.PlayerAt(5)
+	if eve.Count() != 2 || eve.bet != 50 || eve.money != 950 {
+		t.Errorf("Expected Eve to be dealt in posting $50, got %d cards with a bet of %d", eve.Count(), eve.bet)
+	}
+	game.PreFlop()
+	if game.CurrentPlayer() != eve {
+		t.Fatalf("Expected Eve to act first, got %v", game.CurrentPlayer())
+	}
+	if _, ok := game.legalAction(eve, ActionCheck); !ok {
+		t.Errorf("Expected Eve's big blind to be live, got %v", game.LegalActions(eve))
+	}
+}
+
+func TestWaitForBigBlind(t *testing.T) {
+	game := newStartedGame(1000, 50, "Alice", "Bob", "Charlie", "Dave")
+	foldAround(game)
+
+	// Eve takes the seat of the small blind, which gets the button next hand
+	game.Leave("Bob")
+	game.SitDown("Eve", 1, 1000)
+	game.NextHand()
+
+	eve := game.PlayerAt(1)
+	if eve.PlayerStatus != SittingOut || eve.Count() != 0 || eve.bet != 0 {
+		t.Errorf("Expected Eve to wait out the button, got %v with %d cards", eve.PlayerStatus, eve.Count())
+	}
+	if game.dealerName() != "" || game.Players[game.DealerIndex].Name != "Alice" {
+		t.Errorf("Expected a dead button, got dealer %q", game.dealerName())
+	}
+	if game.PlayerAt(2).bet != 25 || game.PlayerAt(3).bet != 50 {
+		t.Errorf("Expected Charlie and Dave to post the blinds, got %d and %d", game.PlayerAt(2).bet, game.PlayerAt(3).bet)
+	}
+
+	// Once the button has passed her she posts the big blind
+	foldAround(game)
+	game.NextHand()
+	if eve := game.PlayerAt(1); eve.Count() != 2 || eve.bet != 50 {
+		t.Errorf("Expected Eve to be dealt in posting $50, got %d cards with a bet of %d", eve.Count(), eve.bet)
+	}
+}
+
+func TestAwaitBigBlind(t *testing.T) {
+	game := newStartedGame(1000, 50, "Alice", "Bob", "Charlie", "Dave")
+	foldAround(game)
+
+	// Eve is moved to the table in the small blind's seat, like a tournament player
+	game.Leave("Bob")
+	game.addPlayer("Eve", 1, 1000, false)
+	eve := game.PlayerAt(1)
+	eve.awaitingBigBlind = true
+	posted := func() int {
+		total := game.deadMoney
+		for _, player := range game.Players {
+			total += player.bet + player.contributed
+		}
+		return total
+	}
+
+	game.NextHand()
+	if eve.PlayerStatus != SittingOut || eve.Count() != 0 || posted() != 75 {
+		t.Errorf("Expected Eve to wait out the button, got %v with %d cards and $%d posted", eve.PlayerStatus, eve.Count(), posted())
+	}
+
+	// Once the button has passed them they are dealt in without owing a blind
+	foldAround(game)
+	game.NextHand()
+	if eve.Count() != 2 || eve.awaitingBigBlind || posted() != 75 {
+		t.Errorf("Expected Eve to be dealt in with only the blinds posted, got %d cards and $%d posted", eve.Count(), posted())
+	}
+}
+
+func TestSitOutMissesBigBlind(t *testing.T) {
+	// Alice deals, Bob posts the small blind and Charlie the big blind
+	game := newStartedGame(1000, 50, "Alice", "Bob", "Charlie", "Dave")
+	if err := game.SitOut("Dave"); err != nil {
+		t.Fatalf("Expected Dave to sit out, got %v", err)
+	}
+	foldAround(game)
+
+	// The big blind skips Dave and moves on to Alice
+	game.NextHand()
+	dave := game.PlayerAt(3)
+	if dave.PlayerStatus != SittingOut || dave.Count() != 0 || !dave.missedBigBlind {
+		t.Errorf("Expected Dave to be dealt out and miss the big blind, got %v with %d cards", dave.PlayerStatus, dave.Count())
+	}
+	if game.PlayerAt(0).bet != 50 {
+		t.Errorf("Expected Alice to post the big blind, got %d", game.PlayerAt(0).bet)
+	}
+	foldAround(game)
+
+	// Back in, he posts the missed big blind live
+	game.SitIn("Dave")
+	game.NextHand()
+	dave = game.PlayerAt(3)
+	if dave.Count() != 2 || dave.bet != 50 || dave.money != 950 || dave.missedBigBlind {
+		t.Errorf("Expected Dave to be dealt in posting $50, got %d cards with a bet of %d", dave.Count(), dave.bet)
+	}
+}
+
+func TestSitOutMissesSmallBlind(t *testing.T) {
+	// Alice deals, Bob posts the small blind and Charlie the big blind
+	game := newStartedGame(1000, 50, "Alice", "Bob", "Charlie", "Dave")
+	game.SitOut("Charlie")
+	foldAround(game)
+
+	// Charlie's small blind is not posted
+	game.NextHand()
+	if !game.PlayerAt(2).missedSmallBlind || game.PlayerAt(2).missedBigBlind {
+		t.Errorf("Expected Charlie to miss only the small blind")
+	}
+	foldAround(game)
+	game.NextHand()
+	foldAround(game)
+
+	// Back in, he posts it as dead money
+	game.SitIn("Charlie")
+	recorder := &eventRecorder{}
+	game.Subscribe(recorder)
+	game.NextHand()
+	charlie := game.PlayerAt(2)
+	if charlie.Count() != 2 || charlie.bet != 0 || charlie.money != 1000 {
+		t.Errorf("Expected Charlie to be dealt in with $1000 and no bet, got %d cards, $%d and a bet of %d", charlie.Count(), charlie.money, charlie.bet)
+	}
+	if !containsEvent(recorder.events, BlindPosted{Player: "Charlie", Kind: DeadSmallBlind, Amount: 25}) {
+		t.Errorf("Expected Charlie to post a dead small blind, got %v", recorder.events)
+	}
+	if game.Pots[0].Amount != 25 {
+		t.Errorf("Expected the dead small blind in the pot, got %d", game.Pots[0].Amount)
+	}
+}
+
+func TestRebuy(t *testing.T) {
+	game := newSeatedGame(1000, 50, "Alice", "Bob", "Charlie")
+	game.MaxBuyIn = 1500
+	var violations []error
+	game.SetAuditor(NewAuditor(func(err error) {
+		violations = append(violations, err)
+	}))
+	game.StartGame()
+	game.PreFlop()
+
+	if err := game.Rebuy("Alice", 100); !errors.Is(err, ErrWrongState) {
+		t.Errorf("Expected rebuying during a hand to fail with %v, got %v", ErrWrongState, err)
+	}
+	foldAround(game)
+
+	for _, amount := range []int{0, 600} {
+		if err := game.Rebuy("Alice", amount); !errors.Is(err, ErrInvalidBuyIn) {
+			t.Errorf("Expected rebuying for $%d to fail with %v, got %v", amount, ErrInvalidBuyIn, err)
+		}
+	}
+	if err := game.Rebuy("Alice", 500); err != nil || game.PlayerAt(0).money != 1500 {
+		t.Errorf("Expected Alice to top up to $1500, got $%d and %v", game.PlayerAt(0).money, err)
+	}
+	if err := game.Rebuy("Eve", 500); !errors.Is(err, ErrPlayerNotFound) {
+		t.Errorf("Expected an unknown player to fail with %v, got %v", ErrPlayerNotFound, err)
+	}
+
+	game.NextHand()
+	if len(violations) > 0 {
+		t.Errorf("Expected the rebuy to keep the chips balanced, got %v", violations)
+	}
+}
+
+func TestLeave(t *testing.T) {
+	game := newStartedGame(1000, 50, "Alice", "Bob", "Charlie")
+	if _, err := game.Leave("Alice"); !errors.Is(err, ErrWrongState) {
+		t.Errorf("Expected leaving during a hand to fail with %v, got %v", ErrWrongState, err)
+	}
+	foldAround(game)
+
+	money, err := game.Leave("Charlie")
+	if err != nil || money != 1025 {
+		t.Errorf("Expected Charlie to leave with $1025, got $%d and %v", money, err)
+	}
+	if game.PlayerAt(2) != nil {
+		t.Errorf("Expected Charlie's seat to be empty, got %+v", game.PlayerAt(2))
+	}
+	if _, err := game.Leave("Charlie"); !errors.Is(err, ErrPlayerNotFound) {
+		t.Errorf("Expected leaving twice to fail with %v, got %v", ErrPlayerNotFound, err)
+	}
+	if err := game.SitDown("Dave", 2, 1000); err != nil {
+		t.Errorf("Expected Dave to take the empty seat, got %v", err)
+	}
+}
+
+// containsEvent reports whether the event was recorded
+func containsEvent(events []Event, e Event) bool {
+	for _, recorded := range events {
+		if recorded == e {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,358 @@
+package poker
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+)
+
+// Snapshot is a copy of the complete state of a game at one moment, from the
+// order of the cards left in the deck to whose turn it is. It shares nothing
+// with the game, so the game can play on while the snapshot stays the same,
+// and it can be encoded, e.g. with encoding/json, to restore the game after a
+// crash. RestoreGame continues a game from a snapshot, as often as needed to
+// try out different lines of play.
+//
+// The record of the current hand is part of the snapshot, so its actions can
+// still be taken back with Undo once the game is restored. The listeners,
+// logger and auditor of a game are not, they are attached again to the
+// restored game.
+type Snapshot struct {
+	ID            string
+	HandNumber    int
+	GameStatus    GameStatus
+	StartingMoney int
+	BigBlind      int
+	SmallBlind    int
+	MaxSeats      int
+	MinBuyIn      int
+	MaxBuyIn      int
+	Ante          int
+	AnteRule      AnteRule
+	StraddleRule  StraddleRule
+	Betting       BettingSnapshot
+	Schedule      *ScheduleSnapshot // Nil when the game has no blind schedule
+	DealerIndex   int
+	OddChipRule   OddChipRule
+	Players       []PlayerSnapshot
+	Deck          []Card // Cards left in the deck, in the order they are dealt
+	Community     []Card
+	Pots          []Pot
+
+	DeadMoney      int
+	HighestBet     int
+	MinRaise       int
+	Raises         int
+	FullBet        int
+	ActionIndex    int
+	NextPlayerID   int
+	ButtonSeat     int
+	SmallBlindSeat int
+	BigBlindSeat   int
+
+	Hand *HandRecord // Record of the current or last hand, nil before the first hand and in the table of a record
+}
+
+// PlayerSnapshot is the state of a player in a Snapshot
+type PlayerSnapshot struct {
+	ID               int
+	Name             string
+	Money            int
+	Bet              int
+	Cards            []Card
+	IsReady          bool
+	IsDealer         bool
+	Status           PlayerStatus
+	HasActed         bool
+	ActedFacing      int
+	Contributed      int
+	Seat             int
+	SittingOut       bool
+	MissedSmallBlind bool
+	MissedBigBlind   bool
+	AwaitingBigBlind bool
+}
+
+// BettingSnapshot is the betting structure of a game in a Snapshot, no-limit
+// when none of the structures is set. Betting structures of your own cannot be
+// encoded: they are kept in the snapshot itself, but RestoreGame rejects a
+// snapshot that has lost them by being encoded and decoded again.
+type BettingSnapshot struct {
+	PotLimit    bool
+	FixedLimit  *FixedLimit
+	SpreadLimit *SpreadLimit
+	Custom      bool // Whether the game uses a betting structure of its own
+
+	custom BettingStructure
+}
+
+// ScheduleSnapshot is the blind schedule of a game in a Snapshot. Its clock
+// cannot be encoded either, a restored schedule uses the system clock unless
+// the snapshot was taken in the same process.
+type ScheduleSnapshot struct {
+	Levels       []Level
+	Level        int // Index of the current level
+	LevelStart   time.Time
+	HandsInLevel int
+	Announced    int
+	Started      bool
+
+	clock Clock
+}
+
+// Snapshot captures the complete state of the game, see Snapshot
+func (g *Game) Snapshot() Snapshot {
+	s := Snapshot{
+		ID:             g.ID,
+		HandNumber:     g.HandNumber,
+		GameStatus:     g.GameStatus,
+		StartingMoney:  g.StartingMoney,
+		BigBlind:       g.BigBlind,
+		SmallBlind:     g.SmallBlind,
+		MaxSeats:       g.MaxSeats,
+		MinBuyIn:       g.MinBuyIn,
+		MaxBuyIn:       g.MaxBuyIn,
+		Ante:           g.Ante,
+		AnteRule:       g.AnteRule,
+		StraddleRule:   g.StraddleRule,
+		Betting:        snapshotBetting(g.Betting),
+		DealerIndex:    g.DealerIndex,
+		OddChipRule:    g.OddChipRule,
+		Players:        []PlayerSnapshot{},
+		Community:      slices.Clone(g.Community.cards),
+		Pots:           []Pot{},
+		DeadMoney:      g.deadMoney,
+		HighestBet:     g.highestBet,
+		MinRaise:       g.minRaise,
+		Raises:         g.raises,
+		FullBet:        g.fullBet,
+		ActionIndex:    g.actionIndex,
+		NextPlayerID:   g.nextPlayerID,
+		ButtonSeat:     g.buttonSeat,
+		SmallBlindSeat: g.smallBlindSeat,
+		BigBlindSeat:   g.bigBlindSeat,
+	}
+	if g.Deck != nil {
+		s.Deck = slices.Clone(g.Deck.cards)
+	}
+	for _, p := range g.Players {
+		s.Players = append(s.Players, PlayerSnapshot{
+			ID:               p.ID,
+			Name:             p.Name,
+			Money:            p.money,
+			Bet:              p.bet,
+			Cards:            slices.Clone(p.cards),
+			IsReady:          p.IsReady,
+			IsDealer:         p.IsDealer,
+			Status:           p.PlayerStatus,
+			HasActed:         p.hasActed,
+			ActedFacing:      p.actedFacing,
+			Contributed:      p.contributed,
+			Seat:             p.seat,
+			SittingOut:       p.sittingOut,
+			MissedSmallBlind: p.missedSmallBlind,
+			MissedBigBlind:   p.missedBigBlind,
+			AwaitingBigBlind: p.awaitingBigBlind,
+		})
+	}
+	for _, pot := range g.Pots {
+		s.Pots = append(s.Pots, Pot{Amount: pot.Amount, Eligible: slices.Clone(pot.Eligible)})
+	}
+	if g.record.Table.Players != nil {
+		record := g.HandRecord()
+		s.Hand = &record
+	}
+	if sched := g.Schedule; sched != nil {
+		s.Schedule = &ScheduleSnapshot{
+			Levels:       slices.Clone(sched.Levels),
+			Level:        sched.level,
+			LevelStart:   sched.levelStart,
+			HandsInLevel: sched.handsInLevel,
+			Announced:    sched.announced,
+			Started:      sched.started,
+			clock:        sched.clock,
+		}
+	}
+	return s
+}
+
+// clone returns a copy of the snapshot that shares nothing with it
+func (s Snapshot) clone() Snapshot {
+	s.Betting = s.Betting.clone()
+	if s.Schedule != nil {
+		schedule := *s.Schedule
+		schedule.Levels = slices.Clone(schedule.Levels)
+		s.Schedule = &schedule
+	}
+	s.Players = slices.Clone(s.Players)
+	for i := range s.Players {
+		s.Players[i].Cards = slices.Clone(s.Players[i].Cards)
+	}
+	s.Deck = slices.Clone(s.Deck)
+	s.Community = slices.Clone(s.Community)
+	s.Pots = slices.Clone(s.Pots)
+	for i := range s.Pots {
+		s.Pots[i].Eligible = slices.Clone(s.Pots[i].Eligible)
+	}
+	if s.Hand != nil {
+		record := s.Hand.clone()
+		s.Hand = &record
+	}
+	return s
+}
+
+// clone returns a copy of the betting structure that shares nothing with it
+func (b BettingSnapshot) clone() BettingSnapshot {
+	if b.FixedLimit != nil {
+		fixed := *b.FixedLimit
+		b.FixedLimit = &fixed
+	}
+	if b.SpreadLimit != nil {
+		spread := *b.SpreadLimit
+		b.SpreadLimit = &spread
+	}
+	return b
+}
+
+// snapshotBetting records the betting structure of a game
+func snapshotBetting(b BettingStructure) BettingSnapshot {
+	switch b := b.(type) {
+	case nil, NoLimit:
+		return BettingSnapshot{}
+	case PotLimit:
+		return BettingSnapshot{PotLimit: true}
+	case FixedLimit:
+		return BettingSnapshot{FixedLimit: &b}
+	case SpreadLimit:
+		return BettingSnapshot{SpreadLimit: &b}
+	default:
+		return BettingSnapshot{Custom: true, custom: b}
+	}
+}
+
+// structure returns the betting structure recorded in the snapshot
+func (b BettingSnapshot) structure() (BettingStructure, error) {
+	switch {
+	case b.Custom:
+		if b.custom == nil {
+			return nil, fmt.Errorf("%w: the game's own betting structure was lost when the snapshot was encoded", ErrInvalidSnapshot)
+		}
+		return b.custom, nil
+	case b.PotLimit:
+		return PotLimit{}, nil
+	case b.FixedLimit != nil:
+		return *b.FixedLimit, nil
+	case b.SpreadLimit != nil:
+		return *b.SpreadLimit, nil
+	default:
+		return nil, nil
+	}
+}
+
+// RestoreGame creates a game in the state captured by the snapshot, ready to
+// continue from where it was taken. It shares nothing with the snapshot.
+// A snapshot that does not describe a valid game is rejected with an
+// ErrInvalidSnapshot error, or an *AuditError when its chips or cards are
+// inconsistent.
+func RestoreGame(s Snapshot) (*Game, error) {
+	return restoreGame(s, nil)
+}
+
+// restoreGame restores the game from the snapshot, writing to the given logger
+func restoreGame(s Snapshot, logger *slog.Logger) (*Game, error) {
+	betting, err := s.Betting.structure()
+	if err != nil {
+		return nil, err
+	}
+	if s.ActionIndex < -1 || s.ActionIndex >= len(s.Players) || s.DealerIndex < -1 || s.DealerIndex >= len(s.Players) {
+		return nil, fmt.Errorf("%w: dealer %d or player to act %d is not one of the %d players", ErrInvalidSnapshot, s.DealerIndex, s.ActionIndex, len(s.Players))
+	}
+
+	g := &Game{
+		ID:             s.ID,
+		HandNumber:     s.HandNumber,
+		Players:        []*Player{},
+		GameStatus:     s.GameStatus,
+		StartingMoney:  s.StartingMoney,
+		BigBlind:       s.BigBlind,
+		SmallBlind:     s.SmallBlind,
+		MaxSeats:       s.MaxSeats,
+		MinBuyIn:       s.MinBuyIn,
+		MaxBuyIn:       s.MaxBuyIn,
+		Ante:           s.Ante,
+		AnteRule:       s.AnteRule,
+		StraddleRule:   s.StraddleRule,
+		Betting:        betting,
+		DealerIndex:    s.DealerIndex,
+		OddChipRule:    s.OddChipRule,
+		Deck:           &Deck{CardStack{slices.Clone(s.Deck)}},
+		Community:      CardStack{slices.Clone(s.Community)},
+		Pots:           []Pot{},
+		deadMoney:      s.DeadMoney,
+		highestBet:     s.HighestBet,
+		minRaise:       s.MinRaise,
+		raises:         s.Raises,
+		fullBet:        s.FullBet,
+		actionIndex:    s.ActionIndex,
+		nextPlayerID:   s.NextPlayerID,
+		buttonSeat:     s.ButtonSeat,
+		smallBlindSeat: s.SmallBlindSeat,
+		bigBlindSeat:   s.BigBlindSeat,
+		logger:         logger,
+	}
+
+	if sched := s.Schedule; sched != nil && (len(sched.Levels) == 0 || sched.Level < 0 || sched.Level >= len(sched.Levels)) {
+		return nil, fmt.Errorf("%w: blind level %d is not one of the %d levels of the schedule", ErrInvalidSnapshot, sched.Level, len(sched.Levels))
+	}
+	ids, seats := map[int]bool{}, map[int]bool{}
+	if s.GameStatus == StartGame && !slices.ContainsFunc(s.Players, func(p PlayerSnapshot) bool { return p.Seat == s.BigBlindSeat }) {
+		return nil, fmt.Errorf("%w: nobody sits in the big blind's seat %d to post it", ErrInvalidSnapshot, s.BigBlindSeat)
+	}
+	for _, p := range s.Players {
+		if ids[p.ID] || seats[p.Seat] {
+			return nil, fmt.Errorf("%w: player %s has the ID %d or seat %d of another player", ErrInvalidSnapshot, p.Name, p.ID, p.Seat)
+		}
+		ids[p.ID], seats[p.Seat] = true, true
+		g.Players = append(g.Players, &Player{
+			ID:               p.ID,
+			Name:             p.Name,
+			money:            p.Money,
+			bet:              p.Bet,
+			CardStack:        CardStack{slices.Clone(p.Cards)},
+			IsReady:          p.IsReady,
+			IsDealer:         p.IsDealer,
+			PlayerStatus:     p.Status,
+			hasActed:         p.HasActed,
+			actedFacing:      p.ActedFacing,
+			contributed:      p.Contributed,
+			seat:             p.Seat,
+			sittingOut:       p.SittingOut,
+			missedSmallBlind: p.MissedSmallBlind,
+			missedBigBlind:   p.MissedBigBlind,
+			awaitingBigBlind: p.AwaitingBigBlind,
+		})
+	}
+	for _, pot := range s.Pots {
+		g.Pots = append(g.Pots, Pot{Amount: pot.Amount, Eligible: slices.Clone(pot.Eligible)})
+	}
+	if s.Hand != nil {
+		g.record = s.Hand.clone()
+	}
+	if sched := s.Schedule; sched != nil {
+		g.Schedule = NewBlindSchedule(sched.clock, slices.Clone(sched.Levels)...)
+		g.Schedule.level = sched.Level
+		g.Schedule.levelStart = sched.LevelStart
+		g.Schedule.handsInLevel = sched.HandsInLevel
+		g.Schedule.announced = sched.Announced
+		g.Schedule.started = sched.Started
+	}
+
+	// The chips in the snapshot are taken as they are, but no card may be in two places
+	auditor := &Auditor{total: g.chipsInPlay()}
+	if err := auditor.Check(g, "restoring a snapshot"); err != nil {
+		return nil, err
+	}
+	g.log().Info("game restored from a snapshot", slog.String("state", g.GameStatus.String()), slog.Int("players", len(g.Players)))
+	return g, nil
+}
@@ -0,0 +1,159 @@
+package poker
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// stacks returns the money of every player in the game
+func stacks(g *Game) map[string]int {
+	money := map[string]int{}
+	for _, player := range g.Players {
+		money[player.Name] = player.money
+	}
+	return money
+}
+
+func TestSnapshotRestore(t *testing.T) {
+	game := newStartedGame(1000, 50, "Alice", "Bob", "Charlie")
+	game.getPlayer("Alice", -1).Call(game)
+	game.getPlayer("Bob", -1).Raise(125, game)
+	snapshot := game.Snapshot()
+
+	restored, err := RestoreGame(snapshot)
+	if err != nil {
+		t.Fatalf("Expected the game to be restored, got %v", err)
+	}
+	if !reflect.DeepEqual(restored.Snapshot(), snapshot) {
+		t.Fatalf("Expected the restored game to match the snapshot")
+	}
+
+	// Both games play out the same way from here
+	for _, g := range []*Game{game, restored} {
+		g.getPlayer("Charlie", -1).Call(g)
+		g.getPlayer("Alice", -1).Call(g)
+		for g.GameStatus != DetermineWinner {
+			g.CurrentPlayer().Check(g)
+		}
+	}
+	if !reflect.DeepEqual(restored.Community.cards, game.Community.cards) {
+		t.Errorf("Expected the same board, got %v and %v", game.Community.cards, restored.Community.cards)
+	}
+	if !reflect.DeepEqual(stacks(restored), stacks(game)) {
+		t.Errorf("Expected the same stacks, got %v and %v", stacks(game), stacks(restored))
+	}
+}
+
+func TestSnapshotIsIndependent(t *testing.T) {
+	game := newStartedGame(1000, 50, "Alice", "Bob", "Charlie")
+	snapshot := game.Snapshot()
+	want := game.Snapshot()
+
+	// Playing on does not change the snapshot
+	game.getPlayer("Alice", -1).Raise(150, game)
+	game.getPlayer("Bob", -1).Fold(game)
+	game.getPlayer("Charlie", -1).Call(game)
+	if !reflect.DeepEqual(snapshot, want) {
+		t.Errorf("Expected the snapshot to stay the same as the game plays on")
+	}
+
+	// And forks of it do not change each other
+	first, _ := RestoreGame(snapshot)
+	second, _ := RestoreGame(snapshot)
+	first.CurrentPlayer().Fold(first)
+	if second.CurrentPlayer().Name != "Alice" || second.CurrentPlayer().PlayerStatus != Thinking {
+		t.Errorf("Expected Alice to be thinking in the second fork, got %v", second.CurrentPlayer())
+	}
+	if !reflect.DeepEqual(second.Snapshot(), want) {
+		t.Errorf("Expected the second fork to match the snapshot")
+	}
+}
+
+func TestSnapshotJSON(t *testing.T) {
+	clock := &fakeClock{time.Date(2024, 1, 1, 18, 0, 0, 0, time.UTC)}
+	game := newSeatedGame(1000, 50, "Alice", "Bob", "Charlie")
+	game.Betting = FixedLimit{SmallBet: 50, BigBet: 100, Cap: 4}
+	game.Schedule = NewBlindSchedule(clock, Level{BigBlind: 50, Duration: time.Hour}, Level{BigBlind: 100})
+	game.StartGame()
+	game.PreFlop()
+	game.getPlayer("Alice", -1).Raise(100, game)
+
+	data, err := json.Marshal(game.Snapshot())
+	if err != nil {
+		t.Fatalf("Expected the snapshot to be encoded, got %v", err)
+	}
+	var decoded Snapshot
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Expected the snapshot to be decoded, got %v", err)
+	}
+	restored, err := RestoreGame(decoded)
+	if err != nil {
+		t.Fatalf("Expected the game to be restored, got %v", err)
+	}
+
+	// Only the clock of the schedule is lost on the way, the system clock takes over
+	want := game.Snapshot()
+	want.Schedule.clock = systemClock{}
+	want.Hand.Table.Schedule.clock = nil
+	if got := restored.Snapshot(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected the decoded game to match, got %+v, want %+v", got, want)
+	}
+	if _, ok := restored.legalAction(restored.CurrentPlayer(), ActionRaise); !ok {
+		t.Errorf("Expected fixed-limit raises to carry on, got %v", restored.LegalActions(restored.CurrentPlayer()))
+	}
+}
+
+// customBetting is a betting structure of a game's own
+type customBetting struct {
+	NoLimit
+}
+
+func TestRestoreGameErrors(t *testing.T) {
+	game := newStartedGame(1000, 50, "Alice", "Bob", "Charlie")
+
+	duplicate := game.Snapshot()
+	duplicate.Community = append(duplicate.Community, duplicate.Players[0].Cards[0])
+	if _, err := RestoreGame(duplicate); !errors.Is(err, ErrInvariantViolated) {
+		t.Errorf("Expected a card in two places to fail with %v, got %v", ErrInvariantViolated, err)
+	}
+
+	outOfRange := game.Snapshot()
+	outOfRange.ActionIndex = 3
+	if _, err := RestoreGame(outOfRange); !errors.Is(err, ErrInvalidSnapshot) {
+		t.Errorf("Expected a missing player to act to fail with %v, got %v", ErrInvalidSnapshot, err)
+	}
+
+	sameSeat := game.Snapshot()
+	sameSeat.Players[1].Seat = sameSeat.Players[0].Seat
+	if _, err := RestoreGame(sameSeat); !errors.Is(err, ErrInvalidSnapshot) {
+		t.Errorf("Expected two players in one seat to fail with %v, got %v", ErrInvalidSnapshot, err)
+	}
+
+	schedule := game.Snapshot()
+	schedule.Schedule = &ScheduleSnapshot{Levels: []Level{{BigBlind: 50}}, Level: 1}
+	if _, err := RestoreGame(schedule); !errors.Is(err, ErrInvalidSnapshot) {
+		t.Errorf("Expected a blind level past the schedule to fail with %v, got %v", ErrInvalidSnapshot, err)
+	}
+
+	// A hand cannot be dealt without a big blind
+	noBigBlind := game.HandRecord().Table
+	noBigBlind.BigBlindSeat = 7
+	if _, err := RestoreGame(noBigBlind); !errors.Is(err, ErrInvalidSnapshot) {
+		t.Errorf("Expected an empty big blind seat to fail with %v, got %v", ErrInvalidSnapshot, err)
+	}
+
+	// A betting structure of the game's own survives in memory, but not when encoded
+	game.Betting = customBetting{}
+	if restored, err := RestoreGame(game.Snapshot()); err != nil || restored.Betting != game.Betting {
+		t.Errorf("Expected the custom betting structure to be restored, got %v and %v", restored, err)
+	}
+	data, _ := json.Marshal(game.Snapshot())
+	var decoded Snapshot
+	json.Unmarshal(data, &decoded)
+	if _, err := RestoreGame(decoded); !errors.Is(err, ErrInvalidSnapshot) {
+		t.Errorf("Expected a lost betting structure to fail with %v, got %v", ErrInvalidSnapshot, err)
+	}
+}
@@ -0,0 +1,111 @@
+package poker
+
+import "slices"
+
+// OddChipRule decides who receives the chips left over when a pot cannot be split evenly
+type OddChipRule int
+
+// OddChipRule enums
+const (
+	OddChipLeftOfButton OddChipRule = iota // One chip each to the winners closest to the left of the button
+	OddChipBySuit                          // One chip each to the winners holding the highest card, ties broken by suit
+)
+
+func (r OddChipRule) String() string {
+	switch r {
+	case OddChipLeftOfButton:
+		return "Left of Button"
+	case OddChipBySuit:
+		return "By Suit"
+	default:
+		panic("invalid odd chip rule value")
+	}
+}
+
+// splitPot divides the amount between the winners, returning each winner's share
+// in the same order. The remainder is handed out one chip at a time following
+// the game's OddChipRule, so no chips are lost.
+func (g *Game) splitPot(amount int, winners []*Player) []int {
+	shares := make([]int, len(winners))
+	if len(winners) == 0 {
+		return shares
+	}
+	for i := range shares {
+		shares[i] = amount / len(winners)
+	}
+
+	order := make([]int, len(winners))
+	for i := range order {
+		order[i] = i
+	}
+	switch g.OddChipRule {
+	case OddChipLeftOfButton:
+		slices.SortStableFunc(order, func(a, b int) int {
+			return g.seatsLeftOfButton(winners[a].ID) - g.seatsLeftOfButton(winners[b].ID)
+		})
+	case OddChipBySuit:
+		slices.SortStableFunc(order, func(a, b int) int {
+			return compareBySuit(highestCard(winners[b]), highestCard(winners[a]))
+		})
+	}
+
+	for i := range amount % len(winners) {
+		shares[order[i]]++
+	}
+	return shares
+}
+
+// seatsLeftOfButton counts the seats from the button to the player with the
+// given ID, the small blind being 1. With a dead button DealerIndex is the
+// player before the empty seat, so the count still starts after it.
+func (g *Game) seatsLeftOfButton(id int) int {
+	for i := range g.Players {
+		if g.Players[i].ID == id {
+			return (i - g.DealerIndex - 1 + 2*len(g.Players)) % len(g.Players)
+		}
+	}
+	return len(g.Players)
+}
+
+// highestCard returns the player's highest hole card by value, then by suit
+func highestCard(p *Player) Card {
+	var best Card
+	p.CardStack.ForEach(func(c Card) {
+		if best == (Card{}) || compareBySuit(c, best) > 0 {
+			best = c
+		}
+	})
+	return best
+}
+
+// compareBySuit orders cards by value with aces high, breaking ties by suit from
+// spades down to clubs. Returns 1 if a is higher, -1 if b is higher, or 0 if they are the same.
+func compareBySuit(a, b Card) int {
+	valueOf := func(c Card) int {
+		if c.Value == Ace {
+			return 14
+		}
+		return int(c.Value)
+	}
+	switch {
+	case valueOf(a) != valueOf(b):
+		if valueOf(a) > valueOf(b) {
+			return 1
+		}
+		return -1
+	case a.Suit != b.Suit:
+		if a.Suit < b.Suit {
+			return 1
+		}
+		return -1
+	default:
+		return 0
+	}
+}
+
+// SplitHighLow divides a pot between the high and low halves of a hi/lo game,
+// the odd chip going to the high hand
+func SplitHighLow(amount int) (high, low int) {
+	low = amount / 2
+	return amount - low, low
+}
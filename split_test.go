@@ -0,0 +1,76 @@
+package poker
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitPotLeftOfButton(t *testing.T) {
+	g := newStartedGame(1000, 50, "Alice", "Bob", "Charlie", "Dave")
+	winners := []*Player{g.Players[0], g.Players[2], g.Players[3]}
+
+	// Charlie and Dave are closest to the left of Alice's button
+	if shares := g.splitPot(101, winners); !reflect.DeepEqual(shares, []int{33, 34, 34}) {
+		t.Errorf("Expected shares [33 34 34], got %v", shares)
+	}
+	if shares := g.splitPot(99, winners); !reflect.DeepEqual(shares, []int{33, 33, 33}) {
+		t.Errorf("Expected shares [33 33 33], got %v", shares)
+	}
+
+	// With the button on Dave, Alice is the first winner to the left
+	g.DealerIndex = 3
+	if shares := g.splitPot(100, winners); !reflect.DeepEqual(shares, []int{34, 33, 33}) {
+		t.Errorf("Expected shares [34 33 33], got %v", shares)
+	}
+}
+
+func TestSplitPotBySuit(t *testing.T) {
+	g := newStartedGame(1000, 50, "Alice", "Bob", "Charlie")
+	g.OddChipRule = OddChipBySuit
+	g.Players[0].CardStack = CardStack{[]Card{{Clubs, King}, {Diamonds, Two}}}
+	g.Players[1].CardStack = CardStack{[]Card{{Hearts, King}, {Clubs, Three}}}
+	g.Players[2].CardStack = CardStack{[]Card{{Clubs, Ace}, {Diamonds, Three}}}
+
+	// The ace is the highest card, then the king of hearts beats the king of clubs
+	if shares := g.splitPot(5, g.Players); !reflect.DeepEqual(shares, []int{1, 2, 2}) {
+		t.Errorf("Expected shares [1 2 2], got %v", shares)
+	}
+	if shares := g.splitPot(7, g.Players[:2]); !reflect.DeepEqual(shares, []int{3, 4}) {
+		t.Errorf("Expected shares [3 4], got %v", shares)
+	}
+}
+
+func TestSplitPotShowdown(t *testing.T) {
+	g := newStartedGame(1000, 50, "Alice", "Bob", "Charlie")
+	g.Deck = &Deck{CardStack{[]Card{
+		{Spades, Ten}, {Spades, Jack}, {Spades, Queen}, {Spades, King}, {Spades, Ace},
+	}}}
+	g.getPlayer("Alice", 0).Call(g)    // Call the big blind
+	g.getPlayer("Bob", 1).Fold(g)      // Fold the small blind
+	g.getPlayer("Charlie", 2).Check(g) // Check the big blind, the royal flush is dealt
+	for range 3 {
+		g.getPlayer("Charlie", 2).Check(g)
+		g.getPlayer("Alice", 0).Check(g)
+	}
+
+	if g.GameStatus != DetermineWinner {
+		t.Fatalf("Expected game status to be DetermineWinner, got %v", g.GameStatus)
+	}
+
+	// The board plays and Charlie, first to the left of the button, gets the odd chip
+	expected := []int{1012, 975, 1013}
+	for i, player := range g.Players {
+		if player.money != expected[i] {
+			t.Errorf("Expected %s to have %d, got %d", player.Name, expected[i], player.money)
+		}
+	}
+}
+
+func TestSplitHighLow(t *testing.T) {
+	if high, low := SplitHighLow(125); high != 63 || low != 62 {
+		t.Errorf("Expected 63 and 62, got %d and %d", high, low)
+	}
+	if high, low := SplitHighLow(100); high != 50 || low != 50 {
+		t.Errorf("Expected 50 and 50, got %d and %d", high, low)
+	}
+}
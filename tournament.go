@@ -0,0 +1,331 @@
+package poker
+
+import (
+	"fmt"
+	"log/slog"
+	"math/rand"
+	"slices"
+	"time"
+)
+
+// Finish records the position a player finished the tournament in, 1 for the winner
+type Finish struct {
+	Player   string
+	Position int
+
+	group string // Players eliminated in the same hand share a group
+	stack int    // Money the player started that hand with
+}
+
+// Tournament runs a multi-table tournament: registrants are seated at random,
+// tables are broken and balanced as players are eliminated, the tables play
+// hand-for-hand on the bubble and the finishing positions are recorded.
+//
+// Each table is a Game the caller plays as usual, calling Tournament.NextHand
+// instead of Game.NextHand once a hand is over so the tournament can move
+// players between tables first.
+type Tournament struct {
+	TableSize     int
+	StartingMoney int
+	Levels        []Level // Blind schedule played at every table
+	Clock         Clock   // Tells the time for the blind schedule, the system clock if nil
+	Paid          int     // Places paid, the tables play hand-for-hand when one more player is left
+	Tables        []*Game
+
+	registrants []string
+	remaining   map[string]bool
+	stacks      map[string]int     // Money each player started their current hand with
+	moving      map[*Game][]Player // Players on their way to a table, seated at its next hand
+	waiting     map[*Game]bool     // Tables done with their hand while playing hand-for-hand
+	rounds      map[*Game]int      // Hand-for-hand round each table's hand is played in, if any
+	finishes    []Finish
+	round       int // Hands played hand-for-hand
+	rand        *rand.Rand
+	logger      *slog.Logger
+	started     bool
+}
+
+// NewTournament creates a tournament with tables of up to tableSize players,
+// each starting with startingMoney and playing the given blind levels
+func NewTournament(tableSize, startingMoney int, levels ...Level) *Tournament {
+	return &Tournament{
+		TableSize:     tableSize,
+		StartingMoney: startingMoney,
+		Levels:        levels,
+		remaining:     map[string]bool{},
+		stacks:        map[string]int{},
+		moving:        map[*Game][]Player{},
+		waiting:       map[*Game]bool{},
+		rounds:        map[*Game]int{},
+		rand:          rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// Register enters a player into the tournament before it starts
+func (t *Tournament) Register(name string) error {
+	if t.started {
+		return fmt.Errorf("%w: player %s cannot register once the tournament has started", ErrWrongState, name)
+	}
+	if t.remaining[name] {
+		return fmt.Errorf("%w: player %s is already registered", ErrDuplicatePlayer, name)
+	}
+	t.registrants = append(t.registrants, name)
+	t.remaining[name] = true
+	return nil
+}
+
+// Start seats the registrants at random over as few tables as possible, with
+// the number of players at each table differing by at most one, and starts
+// the first hand at every table
+func (t *Tournament) Start() error {
+	if t.started {
+		return fmt.Errorf("%w: the tournament has already started", ErrWrongState)
+	}
+	if len(t.registrants) < 2 {
+		return fmt.Errorf("%w: %d players registered, at least 2 are required", ErrNotEnoughPlayers, len(t.registrants))
+	}
+	if t.TableSize < 2 || len(t.Levels) == 0 {
+		return fmt.Errorf("%w: a tournament needs tables of at least 2 players and a blind level", ErrWrongState)
+	}
+	t.started = true
+
+	seats := slices.Clone(t.registrants)
+	t.rand.Shuffle(len(seats), func(i, j int) {
+		seats[i], seats[j] = seats[j], seats[i]
+	})
+
+	tables := (len(seats) + t.TableSize - 1) / t.TableSize
+	for i := range tables {
+		table := NewGame(t.StartingMoney, t.Levels[0].BigBlind)
+		if t.logger != nil {
+			table.SetLogger(t.logger)
+		}
+		table.Schedule = NewBlindSchedule(t.Clock, t.Levels...)
+		table.Subscribe(ListenerFunc(func(e Event) {
+			t.handleEvent(table, e)
+		}))
+		table.MaxSeats = t.TableSize
+		table.Initialise()
+		for j := i; j < len(seats); j += tables {
+			table.AddPlayer(seats[j])
+		}
+		for k := range table.Players {
+			table.Players[k].IsReady = true
+		}
+		t.Tables = append(t.Tables, table)
+	}
+	for _, table := range t.Tables {
+		if err := table.StartGame(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetLogger sets the logger every table writes its messages to, see Game.SetLogger
+func (t *Tournament) SetLogger(l *slog.Logger) {
+	t.logger = l
+	for _, table := range t.Tables {
+		table.SetLogger(l)
+	}
+}
+
+// Remaining returns the number of players still in the tournament
+func (t *Tournament) Remaining() int {
+	return len(t.remaining)
+}
+
+// Finished reports whether a winner has been found
+func (t *Tournament) Finished() bool {
+	return t.started && len(t.remaining) == 1
+}
+
+// HandForHand reports whether the tables are playing hand-for-hand, which
+// they do on the bubble: one player away from the money with more than one table
+func (t *Tournament) HandForHand() bool {
+	return t.Paid > 0 && len(t.remaining) == t.Paid+1 && len(t.Tables) > 1
+}
+
+// Standings returns the finishing positions decided so far, best first
+func (t *Tournament) Standings() []Finish {
+	standings := slices.Clone(t.finishes)
+	slices.SortFunc(standings, func(a, b Finish) int {
+		return a.Position - b.Position
+	})
+	return standings
+}
+
+// NextHand starts the next hand at a table whose hand is over, after breaking
+// or balancing it. A table is broken once the players fit on one table fewer,
+// its players moving to the tables with the fewest players, and returns
+// ErrTableBroken. Otherwise, while it has two players more than the smallest
+// table, the player due the big blind moves there. Moved players are seated
+// at their new table when its next hand starts, after its big blind. They are
+// dealt in without posting a blind, unless they would be on the button or in
+// the small blind, where they wait for the big blind.
+//
+// When playing hand-for-hand, tables wait for each other and ErrHandForHand is
+// returned until the last table is done, which starts the next hand everywhere.
+// A table left with a single player returns ErrNotEnoughPlayers until players
+// are moved to it once another table's hand is over.
+func (t *Tournament) NextHand(table *Game) error {
+	if !slices.Contains(t.Tables, table) {
+		return fmt.Errorf("%w: the table is not part of the tournament", ErrTableBroken)
+	}
+	if table.GameStatus != DetermineWinner {
+		return table.wrongState("start the next hand of the tournament")
+	}
+
+	if !t.HandForHand() {
+		delete(t.rounds, table)
+		return t.nextHand(table)
+	}
+
+	t.waiting[table] = true
+	for _, other := range t.Tables {
+		if !t.waiting[other] {
+			return fmt.Errorf("%w: waiting for the other tables to finish their hand", ErrHandForHand)
+		}
+	}
+	t.round++
+	t.waiting = map[*Game]bool{}
+
+	// Larger tables go first, so the players they move are seated at the smaller ones
+	tables := slices.Clone(t.Tables)
+	slices.SortStableFunc(tables, func(a, b *Game) int {
+		return t.seated(b) - t.seated(a)
+	})
+	var err error
+	for _, other := range tables {
+		t.rounds[other] = t.round
+		if e := t.nextHand(other); other == table {
+			err = e
+		}
+	}
+	return err
+}
+
+// nextHand balances the table and starts its next hand
+func (t *Tournament) nextHand(table *Game) error {
+	tables := (len(t.remaining) + t.TableSize - 1) / t.TableSize
+	if len(t.Tables) > tables {
+		return t.breakTable(table)
+	}
+
+	for _, player := range t.moving[table] {
+		// Moved players never pay an extra blind, they only skip the button and small blind
+		if err := table.addPlayer(player.Name, table.seatAfterBigBlind(), player.money, false); err != nil {
+			return err
+		}
+		table.getPlayer(player.Name, -1).awaitingBigBlind = true
+	}
+	delete(t.moving, table)
+
+	for {
+		smallest := t.smallestTable(table)
+		if smallest == nil || t.seated(table)-t.seated(smallest) <= 1 {
+			break
+		}
+		index := table.playerAtSeat(table.nextSeatInGame(table.bigBlindSeat))
+		if err := t.move(table, table.Players[index].Name, smallest); err != nil {
+			return err
+		}
+	}
+	return table.NextHand()
+}
+
+// breakTable moves every player at the table to the tables with the fewest
+// players and removes it from the tournament
+func (t *Tournament) breakTable(table *Game) error {
+	t.Tables = slices.DeleteFunc(t.Tables, func(other *Game) bool {
+		return other == table
+	})
+	for _, name := range playerNames(table.Players) {
+		if err := t.move(table, name, t.smallestTable(table)); err != nil {
+			return err
+		}
+	}
+	for _, player := range t.moving[table] {
+		to := t.smallestTable(table)
+		t.moving[to] = append(t.moving[to], player)
+	}
+	delete(t.moving, table)
+	return fmt.Errorf("%w: its players have moved to the other tables", ErrTableBroken)
+}
+
+// move takes a player from one table to be seated at another at its next hand
+func (t *Tournament) move(from *Game, name string, to *Game) error {
+	money, err := from.Leave(name)
+	if err != nil {
+		return err
+	}
+	t.moving[to] = append(t.moving[to], Player{Name: name, money: money})
+	return nil
+}
+
+// seated counts the players at a table, including those moving to it
+func (t *Tournament) seated(table *Game) int {
+	return len(table.Players) + len(t.moving[table])
+}
+
+// smallestTable returns the table other than the given one with the fewest players
+func (t *Tournament) smallestTable(except *Game) *Game {
+	var smallest *Game
+	for _, table := range t.Tables {
+		if table != except && (smallest == nil || t.seated(table) < t.seated(smallest)) {
+			smallest = table
+		}
+	}
+	return smallest
+}
+
+// handleEvent keeps track of the stacks at the start of each hand and records
+// the finishing positions as players are eliminated
+func (t *Tournament) handleEvent(table *Game, e Event) {
+	switch e := e.(type) {
+	case HandStarted:
+		for _, player := range table.Players {
+			t.stacks[player.Name] = player.money
+		}
+	case PlayerEliminated:
+		// The bubble may burst during a round, the rest of the round still shares it
+		group := fmt.Sprintf("%s/%d", table.ID, table.HandNumber)
+		if round := t.rounds[table]; round > 0 {
+			group = fmt.Sprintf("round %d", round)
+		}
+		t.finishes = append(t.finishes, Finish{
+			Player:   e.Player,
+			Position: len(t.remaining),
+			group:    group,
+			stack:    t.stacks[e.Player],
+		})
+		delete(t.remaining, e.Player)
+		t.rankGroup(group)
+
+		if len(t.remaining) == 1 {
+			for name := range t.remaining {
+				t.finishes = append(t.finishes, Finish{Player: name, Position: 1})
+			}
+		}
+	}
+}
+
+// rankGroup orders players eliminated in the same hand, or the same round of
+// hand-for-hand play, by the money they started the hand with
+func (t *Tournament) rankGroup(group string) {
+	indices := []int{}
+	positions := []int{}
+	for i, finish := range t.finishes {
+		if finish.group == group {
+			indices = append(indices, i)
+			positions = append(positions, finish.Position)
+		}
+	}
+	slices.Sort(positions)
+	slices.SortStableFunc(indices, func(a, b int) int {
+		return t.finishes[b].stack - t.finishes[a].stack
+	})
+	for k, i := range indices {
+		t.finishes[i].Position = positions[k]
+	}
+}
@@ -0,0 +1,277 @@
+package poker
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// newTournament creates a tournament with the given number of registrants, seated deterministically
+func newTournament(players, tableSize int) *Tournament {
+	t := NewTournament(tableSize, 1000, Level{BigBlind: 50})
+	t.rand = rand.New(rand.NewSource(1))
+	for i := range players {
+		t.Register(fmt.Sprintf("Player %d", i+1))
+	}
+	return t
+}
+
+// foldAround plays the table's hand by folding to the big blind
+func foldAround(g *Game) {
+	if g.GameStatus == StartGame {
+		g.PreFlop()
+	}
+	for g.CurrentPlayer() != nil {
+		g.CurrentPlayer().Fold(g)
+	}
+}
+
+// bust eliminates the named players at the end of the table's hand
+func bust(g *Game, names ...string) {
+	for _, name := range names {
+		g.getPlayer(name, -1).money = 0
+	}
+	g.eliminatePlayers()
+}
+
+func TestTournamentSeating(t *testing.T) {
+	tournament := newTournament(20, 9)
+	if err := tournament.Register("Player 1"); !errors.Is(err, ErrDuplicatePlayer) {
+		t.Errorf("Expected registering twice to fail with %v, got %v", ErrDuplicatePlayer, err)
+	}
+	if err := tournament.Start(); err != nil {
+		t.Fatalf("Expected the tournament to start, got %v", err)
+	}
+	if err := tournament.Register("Player 21"); !errors.Is(err, ErrWrongState) {
+		t.Errorf("Expected registering late to fail with %v, got %v", ErrWrongState, err)
+	}
+
+	sizes := []int{}
+	seated := []string{}
+	for _, table := range tournament.Tables {
+		sizes = append(sizes, len(table.Players))
+		seated = append(seated, playerNames(table.Players)...)
+		if table.GameStatus != StartGame || table.BigBlind != 50 {
+			t.Errorf("Expected every table to have started with a big blind of 50, got %v with %d", table.GameStatus, table.BigBlind)
+		}
+	}
+	if !slices.Equal(sizes, []int{7, 7, 6}) {
+		t.Errorf("Expected tables of 7, 7 and 6 players, got %v", sizes)
+	}
+	slices.Sort(seated)
+	if len(slices.Compact(seated)) != 20 {
+		t.Errorf("Expected all 20 players to be seated once, got %v", seated)
+	}
+}
+
+func TestTournamentBalancing(t *testing.T) {
+	tournament := newTournament(12, 6)
+	tournament.Start()
+	first, second := tournament.Tables[0], tournament.Tables[1]
+
+	// Two players bust at the first table
+	foldAround(first)
+	bust(first, first.Players[0].Name, first.Players[1].Name)
+	if err := tournament.NextHand(first); err != nil {
+		t.Fatalf("Expected the first table to play on, got %v", err)
+	}
+
+	// The second table has two more players, so the player due the big blind moves
+	foldAround(second)
+	moving := second.Players[second.playerAtSeat(second.nextSeatInGame(second.bigBlindSeat))]
+	if err := tournament.NextHand(second); err != nil {
+		t.Fatalf("Expected the second table to play on, got %v", err)
+	}
+	if len(second.Players) != 5 || second.getPlayer(moving.Name, -1) != nil {
+		t.Errorf("Expected %s to leave the second table, got %v", moving.Name, playerNames(second.Players))
+	}
+
+	// And is dealt in at the first table's next hand, after its big blind and without posting
+	foldAround(first)
+	if err := tournament.NextHand(first); err != nil {
+		t.Fatalf("Expected the first table to play on, got %v", err)
+	}
+	if player := first.getPlayer(moving.Name, -1); player == nil || player.Count() != 2 || player.money != moving.money || player.bet != 0 {
+		t.Errorf("Expected %s to be dealt in at the first table without posting, got %+v", moving.Name, player)
+	}
+
+	// Once everyone fits on one table, the first table to finish its hand is broken
+	foldAround(first)
+	bust(first, playerNames(first.Players[:4])...)
+	if err := tournament.NextHand(first); !errors.Is(err, ErrTableBroken) {
+		t.Fatalf("Expected the first table to be broken, got %v", err)
+	}
+	if len(tournament.Tables) != 1 || tournament.seated(second) != 6 {
+		t.Errorf("Expected 6 players left at the second table, got %d at %d tables", tournament.seated(second), len(tournament.Tables))
+	}
+
+	// The players from the broken table pay no blinds on top of the table's own
+	foldAround(second)
+	if err := tournament.NextHand(second); err != nil {
+		t.Fatalf("Expected the second table to play on, got %v", err)
+	}
+	posted := second.deadMoney
+	for _, player := range second.Players {
+		posted += player.bet + player.contributed
+	}
+	if posted != 75 {
+		t.Errorf("Expected only the $25 and $50 blinds to be posted, got $%d", posted)
+	}
+	if err := tournament.NextHand(first); !errors.Is(err, ErrTableBroken) {
+		t.Errorf("Expected the broken table to stay broken, got %v", err)
+	}
+}
+
+func TestTournamentRanking(t *testing.T) {
+	tournament := newTournament(4, 4)
+	tournament.Start()
+	table := tournament.Tables[0]
+	for i, player := range table.Players {
+		tournament.stacks[player.Name] = []int{300, 100, 200, 400}[i]
+	}
+	names := playerNames(table.Players)
+
+	// The players eliminated in the same hand are ranked by the money they started it with
+	tournament.handleEvent(table, PlayerEliminated{Player: names[0]})
+	tournament.handleEvent(table, PlayerEliminated{Player: names[1]})
+	tournament.handleEvent(table, PlayerEliminated{Player: names[2]})
+
+	expected := []Finish{
+		{Player: names[3], Position: 1},
+		{Player: names[0], Position: 2},
+		{Player: names[2], Position: 3},
+		{Player: names[1], Position: 4},
+	}
+	standings := tournament.Standings()
+	if len(standings) != len(expected) {
+		t.Fatalf("Expected %d finishes, got %v", len(expected), standings)
+	}
+	for i, finish := range standings {
+		if finish.Player != expected[i].Player || finish.Position != expected[i].Position {
+			t.Errorf("Expected %s to finish in position %d, got %s in %d", expected[i].Player, expected[i].Position, finish.Player, finish.Position)
+		}
+	}
+	if !tournament.Finished() {
+		t.Errorf("Expected the tournament to be finished")
+	}
+}
+
+func TestTournamentPlaysOut(t *testing.T) {
+	tournament := newTournament(20, 6)
+	tournament.Paid = 3
+	tournament.Start()
+
+	for step := 0; !tournament.Finished(); step++ {
+		if step > 100000 {
+			t.Fatalf("Expected the tournament to finish, %d players remain", tournament.Remaining())
+		}
+		for _, table := range slices.Clone(tournament.Tables) {
+			switch table.GameStatus {
+			case StartGame:
+				table.PreFlop()
+			case DetermineWinner:
+				err := tournament.NextHand(table)
+				if err != nil && !errors.Is(err, ErrHandForHand) && !errors.Is(err, ErrTableBroken) && !errors.Is(err, ErrNotEnoughPlayers) {
+					t.Fatalf("Unexpected error starting the next hand: %v", err)
+				}
+			default:
+				// Everyone goes All In, or calls when they cannot
+				player := table.CurrentPlayer()
+				if _, ok := table.legalAction(player, ActionAllIn); ok {
+					player.AllIn(table)
+				} else {
+					player.Call(table)
+				}
+			}
+		}
+
+		// Chips are only moved between players and tables
+		chips := 0
+		for _, table := range tournament.Tables {
+			chips += table.chipsInPlay()
+			for _, player := range tournament.moving[table] {
+				chips += player.money
+			}
+		}
+		if chips != 20000 {
+			t.Fatalf("Expected 20000 chips in play, got %d", chips)
+		}
+		for _, table := range tournament.Tables {
+			if table.GameStatus == StartGame && len(tournament.Tables) > 1 {
+				for _, other := range tournament.Tables {
+					if len(table.Players)-tournament.seated(other) > 1 {
+						t.Fatalf("Expected a table to start its hand balanced, got %d and %d players", len(table.Players), tournament.seated(other))
+					}
+				}
+			}
+		}
+	}
+
+	standings := tournament.Standings()
+	if len(standings) != 20 {
+		t.Fatalf("Expected 20 finishes, got %d", len(standings))
+	}
+	for i, finish := range standings {
+		if finish.Position != i+1 {
+			t.Errorf("Expected position %d, got %+v", i+1, finish)
+		}
+	}
+}
+
+func TestTournamentHandForHand(t *testing.T) {
+	for _, smallFirst := range []bool{true, false} {
+		testTournamentHandForHand(t, smallFirst)
+	}
+}
+
+// testTournamentHandForHand plays the bubble, busting the short stack first or last
+func testTournamentHandForHand(t *testing.T, smallFirst bool) {
+	tournament := newTournament(6, 3)
+	tournament.Paid = 4
+	tournament.Start()
+	first, second := tournament.Tables[0], tournament.Tables[1]
+
+	// The bubble bursts with five players left
+	foldAround(first)
+	bust(first, first.Players[0].Name)
+	if !tournament.HandForHand() {
+		t.Fatalf("Expected hand-for-hand play with %d players left", tournament.Remaining())
+	}
+	if err := tournament.NextHand(first); !errors.Is(err, ErrHandForHand) {
+		t.Fatalf("Expected the first table to wait with %v, got %v", ErrHandForHand, err)
+	}
+	if first.GameStatus != DetermineWinner {
+		t.Errorf("Expected the first table to wait for the second, got %v", first.GameStatus)
+	}
+
+	// The last table to finish starts the next hand everywhere
+	foldAround(second)
+	if err := tournament.NextHand(second); err != nil {
+		t.Fatalf("Expected the second table to start the next hand, got %v", err)
+	}
+	if first.GameStatus != StartGame || second.GameStatus != StartGame {
+		t.Fatalf("Expected both tables to start their hand, got %v and %v", first.GameStatus, second.GameStatus)
+	}
+
+	// Players busting at different tables in the same hand share it, ranked by their stacks
+	foldAround(first)
+	foldAround(second)
+	small, big := first.Players[0].Name, second.Players[0].Name
+	tournament.stacks[small], tournament.stacks[big] = 500, 1500
+	if smallFirst {
+		bust(first, small)
+		bust(second, big)
+	} else {
+		bust(second, big)
+		bust(first, small)
+	}
+	standings := tournament.Standings()
+	if standings[0].Player != big || standings[0].Position != 4 || standings[1].Player != small || standings[1].Position != 5 {
+		t.Errorf("Expected %s to finish 4th and %s 5th whoever busts first, got %+v", big, small, standings)
+	}
+	if tournament.HandForHand() {
+		t.Errorf("Expected hand-for-hand play to end once the bubble has burst")
+	}
+}
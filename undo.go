@@ -0,0 +1,88 @@
+package poker
+
+import (
+	"fmt"
+	"log/slog"
+	"reflect"
+)
+
+// Undo takes back the last action of the hand, a misclicked fold or a raise of
+// the wrong amount, restoring the stacks, bets, statuses and pots as they were
+// before it and giving the turn back to the player. Earlier actions can be taken
+// back one by one, even once the action has ended the hand, but not after the
+// next hand has started.
+//
+// The game's ApproveUndo hook, when set, lets the host refuse the undo with
+// an ErrUndoRefused error. Undo returns ErrNothingToUndo when no action of the
+// hand is left, and ErrWrongState when players have joined, left or rebought
+// since the action.
+func (g *Game) Undo() error {
+	if !g.handInProgress() && g.GameStatus != DetermineWinner {
+		return g.wrongState("undo an action")
+	}
+	if len(g.record.Actions) == 0 {
+		return fmt.Errorf("%w: no action has been taken in hand %d", ErrNothingToUndo, g.HandNumber)
+	}
+	last := g.record.Actions[len(g.record.Actions)-1]
+
+	// Replay the whole hand first, it has to lead to the game as it is, without
+	// logging the hand a second time
+	replayer, err := newReplayer(g.record, slog.New(slog.DiscardHandler))
+	if err != nil {
+		return err
+	}
+	if err := replayer.replay(replayer.Len()); err != nil {
+		return err
+	}
+	if replayer.game.GameStatus == StartGame && g.GameStatus != StartGame {
+		if err := replayer.game.PreFlop(); err != nil {
+			return err
+		}
+	}
+	replayed, current := replayer.game.Snapshot(), g.Snapshot()
+	replayed.Hand, current.Hand = nil, nil
+	if !reflect.DeepEqual(replayed, current) {
+		return g.wrongState(fmt.Sprintf("undo player %s's action after the table has changed", last.Player))
+	}
+
+	// Then up to the action, which the player was about to take
+	if err := replayer.replay(replayer.Len() - 1); err != nil {
+		return err
+	}
+	if !last.Straddle && replayer.game.GameStatus == StartGame {
+		if err := replayer.game.PreFlop(); err != nil {
+			return err
+		}
+	}
+
+	if g.ApproveUndo != nil && !g.ApproveUndo(last) {
+		return fmt.Errorf("%w: the host did not approve taking back player %s's action", ErrUndoRefused, last.Player)
+	}
+	g.restore(replayer.game)
+	g.emit(ActionUndone{Player: last.Player, Action: last.Type, Amount: last.Amount, Straddle: last.Straddle})
+	g.audit(fmt.Sprintf("undoing player %s's action", last.Player))
+	return nil
+}
+
+// restore puts the game in the state of the replayed game. Players are kept
+// by pointer, and the blind schedule, listeners, logger, auditor and hooks
+// stay attached.
+func (g *Game) restore(replayed *Game) {
+	players := map[int]*Player{}
+	for _, p := range g.Players {
+		players[p.ID] = p
+	}
+	for i, p := range replayed.Players {
+		if player, ok := players[p.ID]; ok {
+			*player = *p
+			replayed.Players[i] = player
+		}
+	}
+
+	replayed.listeners, replayed.nextListener = g.listeners, g.nextListener
+	replayed.logger = g.logger
+	replayed.auditor, replayed.unsubscribeAuditor = g.auditor, g.unsubscribeAuditor
+	replayed.Schedule = g.Schedule
+	replayed.SeedSource, replayed.ApproveUndo = g.SeedSource, g.ApproveUndo
+	*g = *replayed
+}
@@ -0,0 +1,143 @@
+package poker
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestUndo(t *testing.T) {
+	game := newStartedGame(1000, 50, "Alice", "Bob", "Charlie")
+	game.SetAuditor(NewAuditor(func(err error) { t.Error(err) }))
+	recorder := &eventRecorder{}
+	game.Subscribe(recorder)
+	bob := game.getPlayer("Bob", -1)
+
+	game.getPlayer("Alice", -1).Raise(150, game)
+	before := game.Snapshot()
+	bob.Fold(game)
+
+	// Bob misclicked, they are back in the hand with the turn
+	if err := game.Undo(); err != nil {
+		t.Fatalf("Expected the fold to be taken back, got %v", err)
+	}
+	if got := game.Snapshot(); !reflect.DeepEqual(got, before) {
+		t.Errorf("Expected the game to be as before the fold, got %+v, want %+v", got, before)
+	}
+	if game.CurrentPlayer() != bob || game.Players[1] != bob {
+		t.Errorf("Expected Bob to keep their place and have the turn, got %v", game.CurrentPlayer())
+	}
+	last := recorder.events[len(recorder.events)-1]
+	if want := (ActionUndone{Player: "Bob", Action: ActionFold}); last != want {
+		t.Errorf("Expected %+v to be emitted, got %+v", want, last)
+	}
+
+	// The game carries on from there, and the raise can be taken back too
+	if err := bob.Call(game); err != nil {
+		t.Fatalf("Expected Bob to call, got %v", err)
+	}
+	game.Undo()
+	game.Undo()
+	if game.CurrentPlayer().Name != "Alice" || game.highestBet != 50 || game.getPlayer("Alice", -1).money != 1000 {
+		t.Errorf("Expected Alice to act again facing the big blind, got %v to act facing $%d", game.CurrentPlayer(), game.highestBet)
+	}
+	if err := game.Undo(); !errors.Is(err, ErrNothingToUndo) {
+		t.Errorf("Expected %v once every action is taken back, got %v", ErrNothingToUndo, err)
+	}
+}
+
+func TestUndoEndOfHand(t *testing.T) {
+	game := newStartedGame(1000, 50, "Alice", "Bob", "Charlie")
+	game.SetAuditor(NewAuditor(func(err error) { t.Error(err) }))
+	foldAround(game)
+	if game.GameStatus != DetermineWinner {
+		t.Fatalf("Expected the hand to be over, got %v", game.GameStatus)
+	}
+
+	// Taking back the last fold reopens the hand
+	if err := game.Undo(); err != nil {
+		t.Fatalf("Expected the fold to be taken back, got %v", err)
+	}
+	bob := game.getPlayer("Bob", -1)
+	if game.GameStatus != PreFlop || game.CurrentPlayer() != bob || bob.bet != 25 || game.getPlayer("Charlie", -1).money != 950 {
+		t.Errorf("Expected Bob to act in the small blind, got %v to act in %v", game.CurrentPlayer(), game.GameStatus)
+	}
+
+	// Not once the next hand has started
+	foldAround(game)
+	game.NextHand()
+	if err := game.Undo(); !errors.Is(err, ErrNothingToUndo) {
+		t.Errorf("Expected the last hand's actions to be final with %v, got %v", ErrNothingToUndo, err)
+	}
+}
+
+func TestUndoApproval(t *testing.T) {
+	game := newStartedGame(1000, 50, "Alice", "Bob", "Charlie")
+	approved := false
+	asked := []Action{}
+	game.ApproveUndo = func(a Action) bool {
+		asked = append(asked, a)
+		return approved
+	}
+	game.getPlayer("Alice", -1).Raise(150, game)
+	want := game.Snapshot()
+
+	if err := game.Undo(); !errors.Is(err, ErrUndoRefused) {
+		t.Errorf("Expected the host to refuse with %v, got %v", ErrUndoRefused, err)
+	}
+	if !reflect.DeepEqual(game.Snapshot(), want) {
+		t.Errorf("Expected a refused undo to leave the game as it is")
+	}
+	approved = true
+	if err := game.Undo(); err != nil {
+		t.Errorf("Expected the host to approve, got %v", err)
+	}
+	expected := Action{Player: "Alice", Type: ActionRaise, Amount: 150}
+	if len(asked) != 2 || asked[0] != expected {
+		t.Errorf("Expected the host to be asked about %+v twice, got %v", expected, asked)
+	}
+}
+
+func TestUndoAfterRestore(t *testing.T) {
+	game := newStartedGame(1000, 50, "Alice", "Bob", "Charlie")
+	game.getPlayer("Alice", -1).Raise(150, game)
+	before := game.Snapshot()
+	game.getPlayer("Bob", -1).Fold(game)
+
+	// The game comes back from a crash and Bob's fold is still taken back
+	data, err := json.Marshal(game.Snapshot())
+	if err != nil {
+		t.Fatalf("Expected the snapshot to be encoded, got %v", err)
+	}
+	var decoded Snapshot
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Expected the snapshot to be decoded, got %v", err)
+	}
+	restored, err := RestoreGame(decoded)
+	if err != nil {
+		t.Fatalf("Expected the game to be restored, got %v", err)
+	}
+	if err := restored.Undo(); err != nil {
+		t.Fatalf("Expected the fold to be taken back after the restore, got %v", err)
+	}
+	if got := restored.Snapshot(); !reflect.DeepEqual(got.Players, before.Players) || got.ActionIndex != before.ActionIndex {
+		t.Errorf("Expected the players to be as before the fold, got %+v, want %+v", got.Players, before.Players)
+	}
+	if err := restored.Undo(); err != nil || restored.CurrentPlayer().Name != "Alice" {
+		t.Errorf("Expected the raise to be taken back too, got %v with %v to act", err, restored.CurrentPlayer())
+	}
+}
+
+func TestUndoAfterTableChange(t *testing.T) {
+	game := newStartedGame(1000, 50, "Alice", "Bob", "Charlie")
+	game.getPlayer("Alice", -1).Fold(game)
+	game.SitDown("Dave", 5, 1000)
+
+	if err := game.Undo(); !errors.Is(err, ErrWrongState) {
+		t.Errorf("Expected undoing after a player sat down to fail with %v, got %v", ErrWrongState, err)
+	}
+	if game.PlayerAt(5) == nil || game.getPlayer("Alice", -1).PlayerStatus != Folded {
+		t.Errorf("Expected the game to stay as it is")
+	}
+}